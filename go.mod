@@ -5,15 +5,22 @@ go 1.22.0
 require (
 	github.com/VU-ASE/rovercom v1.0.2
 	github.com/VU-ASE/roverlib v1.0.3
+	github.com/go-yaml/yaml v2.1.0+incompatible
+	github.com/gorilla/websocket v1.5.3
 	github.com/pebbe/zmq4 v1.2.11
+	github.com/prometheus/client_golang v1.19.1
 	github.com/rs/zerolog v1.33.0
 	google.golang.org/protobuf v1.34.2
 )
 
 require (
-	github.com/go-yaml/yaml v2.1.0+incompatible // indirect
-	github.com/kr/pretty v0.2.1 // indirect
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/kr/text v0.2.0 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
-	golang.org/x/sys v0.14.0 // indirect
+	github.com/prometheus/client_model v0.5.0 // indirect
+	github.com/prometheus/common v0.48.0 // indirect
+	github.com/prometheus/procfs v0.12.0 // indirect
+	golang.org/x/sys v0.22.0 // indirect
 )
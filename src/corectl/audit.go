@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	pb_core_extensions "vu/ase/core/src/extensions"
+)
+
+func showAudit(args []string) error {
+	flags := flag.NewFlagSet("audit", flag.ExitOnError)
+	key := flags.String("key", "", "only show the changes to this tuning parameter")
+	since := flags.Duration("since", 0, "only show the changes of this long ago or later, such as 1h (all changes if 0)")
+	asJSON := flags.Bool("json", false, "print the audit trail as JSON")
+	_ = flags.Parse(args)
+
+	auditRequest := &pb_core_extensions.TuningAuditRequest{Key: *key}
+	if *since > 0 {
+		auditRequest.From = uint64(time.Now().Add(-*since).UnixMilli())
+	}
+	reply, err := requestExtension(&pb_core_extensions.CoreExtensionMessage{
+		Msg: &pb_core_extensions.CoreExtensionMessage_TuningAuditRequest{
+			TuningAuditRequest: auditRequest,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	trail := reply.GetTuningAuditTrail()
+	if *asJSON {
+		return printJSON(trail)
+	}
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "TIME\tREVISION\tKEY\tOLD\tNEW\tCLIENT")
+	for _, r := range trail.GetRecords() {
+		client := r.GetClient()
+		if client == "" {
+			client = "-"
+		}
+		timestamp := time.UnixMilli(int64(r.GetTimestamp())).Format("2006-01-02 15:04:05.000")
+		fmt.Fprintf(table, "%s\t%d\t%s\t%s\t%s\t%s\n", timestamp, r.GetRevision(), r.GetChange().GetKey(), formatParameterValue(r.GetChange().GetOld()), formatParameterValue(r.GetChange().GetNew()), client)
+	}
+	return table.Flush()
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	pb_core_messages "github.com/VU-ASE/rovercom/packages/go/core"
+	zmq "github.com/pebbe/zmq4"
+	"google.golang.org/protobuf/proto"
+)
+
+// An error reply from core
+type coreError struct {
+	details *pb_core_extensions.DetailedError
+}
+
+func (e *coreError) Error() string {
+	message := e.details.Message
+	for _, v := range e.details.TuningViolations {
+		message += fmt.Sprintf("\n  %s: %s", v.Key, v.Reason)
+	}
+	return message
+}
+
+// Sends a single request to the req/rep server of core and returns the raw reply.
+// Error replies (which are wire compatible with CoreExtensionMessage) are returned as a *coreError
+func request(message proto.Message) ([]byte, error) {
+	socket, err := zmq.NewSocket(zmq.REQ)
+	if err != nil {
+		return nil, err
+	}
+	defer socket.Close()
+	// Do not wait for unsent requests when core is unreachable
+	err = socket.SetLinger(0)
+	if err != nil {
+		return nil, err
+	}
+	err = socket.SetRcvtimeo(*timeout)
+	if err != nil {
+		return nil, err
+	}
+	if *identity != "" {
+		err = socket.SetIdentity(*identity)
+		if err != nil {
+			return nil, fmt.Errorf("Could not use identity '%s': %v", *identity, err)
+		}
+	}
+	err = socket.Connect(*serverAddr)
+	if err != nil {
+		return nil, fmt.Errorf("Could not connect to core at %s: %v", *serverAddr, err)
+	}
+
+	body, err := proto.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	_, err = socket.SendBytes(body, 0)
+	if err != nil {
+		return nil, fmt.Errorf("Could not send request to core at %s: %v", *serverAddr, err)
+	}
+	reply, err := socket.RecvBytes(0)
+	if err != nil {
+		return nil, fmt.Errorf("No reply from core at %s within %s: %v", *serverAddr, timeout.String(), err)
+	}
+
+	parsed := pb_core_extensions.CoreExtensionMessage{}
+	if proto.Unmarshal(reply, &parsed) == nil && parsed.GetError() != nil {
+		return nil, &coreError{parsed.GetError()}
+	}
+	return reply, nil
+}
+
+// Sends one of the rovercom CoreMessages and returns the reply
+func requestCore(message *pb_core_messages.CoreMessage) (*pb_core_messages.CoreMessage, error) {
+	reply, err := request(message)
+	if err != nil {
+		return nil, err
+	}
+	parsed := &pb_core_messages.CoreMessage{}
+	err = proto.Unmarshal(reply, parsed)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse reply from core: %v", err)
+	}
+	return parsed, nil
+}
+
+// Sends one of the messages that core supports on top of CoreMessage and returns the reply
+func requestExtension(message *pb_core_extensions.CoreExtensionMessage) (*pb_core_extensions.CoreExtensionMessage, error) {
+	reply, err := request(message)
+	if err != nil {
+		return nil, err
+	}
+	parsed := &pb_core_extensions.CoreExtensionMessage{}
+	err = proto.Unmarshal(reply, parsed)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse reply from core: %v", err)
+	}
+	return parsed, nil
+}
+
+func getServices() ([]*pb_core_messages.Service, error) {
+	reply, err := requestCore(&pb_core_messages.CoreMessage{
+		Msg: &pb_core_messages.CoreMessage_ServiceListRequest{
+			ServiceListRequest: &pb_core_messages.ServiceListRequest{},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply.GetServiceList().GetServices(), nil
+}
+
+func getTuningState() (*pb_core_messages.TuningState, error) {
+	reply, err := requestCore(&pb_core_messages.CoreMessage{
+		Msg: &pb_core_messages.CoreMessage_TuningStateRequest{
+			TuningStateRequest: &pb_core_messages.TuningStateRequest{},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply.GetTuningState(), nil
+}
+
+// Returns the option with this name and the service that declares it
+func findOption(services []*pb_core_messages.Service, key string) (*pb_core_messages.ServiceOption, *pb_core_messages.Service) {
+	for _, s := range services {
+		for _, o := range s.GetOptions() {
+			if o.GetName() == key {
+				return o, s
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Formats the endpoints of a service as name=address pairs
+func formatEndpoints(endpoints []*pb_core_messages.ServiceEndpoint) string {
+	formatted := make([]string, 0, len(endpoints))
+	for _, e := range endpoints {
+		formatted = append(formatted, fmt.Sprintf("%s=%s", e.GetName(), e.GetAddress()))
+	}
+	return strings.Join(formatted, " ")
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+)
+
+// The endpoints of core, as declared in its service.yaml
+var serverAddr = flag.String("server", "tcp://localhost:1337", "address of the req/rep server of core")
+var broadcastAddr = flag.String("broadcast", "tcp://localhost:1338", "address of the broadcast socket of core")
+var timeout = flag.Duration("timeout", 5*time.Second, "time to wait for a reply from core")
+var identity = flag.String("identity", defaultIdentity(), "identity to present to core, which records it in the audit log for the tuning changes that are made")
+
+type command struct {
+	usage       string
+	description string
+	run         func(args []string) error
+}
+
+var commands = map[string]command{
+	"services": {"services [-json]", "list the registered services", listServices},
+	"service":  {"service [-json] <name>", "show the instances of a service, with their endpoints and options", showService},
+	"get":      {"get <key>", "print the value of a tuning parameter", getParameter},
+	"set":      {"set <key> <value>", "change a tuning parameter, the value must match the type of the option that declares it", setParameter},
+	"audit":    {"audit [-key k] [-since d]", "show the audit trail of the changes to the tuning state (-json for JSON)", showAudit},
+	"tail":     {"tail [topic...]", "print broadcasts as they come in, optionally only those with one of the topic prefixes", tailBroadcasts},
+	"replay":   {"replay [-speed x] <file>", "publish the broadcasts that core recorded (with -record) again, with the recorded timing", replayBroadcasts},
+}
+
+// The order in which the commands are listed in the usage
+var commandOrder = []string{"services", "service", "get", "set", "audit", "tail", "replay"}
+
+// Identifies the user and the process, since core does not accept two clients with the same identity at once
+func defaultIdentity() string {
+	name := "unknown"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("corectl/%s@%s/%d", name, host, os.Getpid())
+}
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), "Usage: corectl [flags] <command> [arguments]\n\nCommands:\n")
+	for _, name := range commandOrder {
+		fmt.Fprintf(flag.CommandLine.Output(), "  %-26s %s\n", commands[name].usage, commands[name].description)
+	}
+	fmt.Fprintf(flag.CommandLine.Output(), "\nFlags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "corectl: unknown command '%s'\n\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	err := cmd.run(flag.Args()[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "corectl: %v\n", err)
+		os.Exit(1)
+	}
+}
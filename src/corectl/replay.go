@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	zmq "github.com/pebbe/zmq4"
+)
+
+func replayBroadcasts(args []string) error {
+	flags := flag.NewFlagSet("replay", flag.ExitOnError)
+	bind := flags.String("bind", "tcp://*:1338", "address to publish the broadcasts on, services subscribe to it as if it were core")
+	speed := flags.Float64("speed", 1, "how much faster than recorded to replay, or 0 to replay without any delay")
+	wait := flags.Duration("wait", time.Second, "time to give subscribers to connect before the replay starts")
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 {
+		return fmt.Errorf("Expected the path of a recording, see corectl -h")
+	}
+	if *speed < 0 {
+		return fmt.Errorf("Speed must not be negative")
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	reader, err := pb_core_extensions.NewRecordingReader(file)
+	if err != nil {
+		return err
+	}
+
+	socket, err := zmq.NewSocket(zmq.PUB)
+	if err != nil {
+		return err
+	}
+	defer socket.Close()
+	err = socket.Bind(*bind)
+	if err != nil {
+		return fmt.Errorf("Could not publish on %s: %v", *bind, err)
+	}
+	// Subscribers that are not connected yet miss whatever is published, there is no way to tell when they are
+	time.Sleep(*wait)
+
+	var previous time.Time
+	replayed := 0
+	for {
+		recorded, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if errors.Is(err, io.ErrUnexpectedEOF) {
+			fmt.Printf("recording ends with an incomplete broadcast, which is skipped\n")
+			break
+		} else if err != nil {
+			return err
+		}
+
+		// Keep the time between broadcasts as it was recorded
+		if !previous.IsZero() && *speed > 0 {
+			time.Sleep(time.Duration(float64(recorded.Time.Sub(previous)) / *speed))
+		}
+		previous = recorded.Time
+
+		_, err = socket.SendMessage(recorded.Frames)
+		if err != nil {
+			return fmt.Errorf("Could not publish broadcast: %v", err)
+		}
+		replayed++
+
+		broadcast, err := pb_core_extensions.ParseBroadcast(recorded.Frames)
+		if err != nil {
+			fmt.Printf("%s  replayed a broadcast that could not be parsed: %v\n", recorded.Time.Format(time.TimeOnly), err)
+			continue
+		}
+		fmt.Printf("%s  #%-6d %-24s %s\n", recorded.Time.Format(time.TimeOnly), broadcast.Sequence, broadcast.Topic, describeBroadcast(broadcast.Message))
+	}
+
+	fmt.Printf("replayed %d broadcast(s)\n", replayed)
+	return nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"vu/ase/core/src/services"
+
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	pb_core_messages "github.com/VU-ASE/rovercom/packages/go/core"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func printJSON(message proto.Message) error {
+	out, err := protojson.MarshalOptions{Multiline: true}.Marshal(message)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+func listServices(args []string) error {
+	flags := flag.NewFlagSet("services", flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "print the service list as JSON")
+	_ = flags.Parse(args)
+
+	list, err := getServices()
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(&pb_core_messages.ServiceList{Services: list})
+	}
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "NAME\tPID\tSTATUS\tOPTIONS\tENDPOINTS")
+	for _, s := range list {
+		fmt.Fprintf(table, "%s\t%d\t%s\t%d\t%s\n", s.GetIdentifier().GetName(), s.GetIdentifier().GetPid(), s.GetStatus().String(), len(s.GetOptions()), formatEndpoints(s.GetEndpoints()))
+	}
+	return table.Flush()
+}
+
+func showService(args []string) error {
+	flags := flag.NewFlagSet("service", flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "print the instances as JSON")
+	_ = flags.Parse(args)
+	if flags.NArg() == 0 {
+		return fmt.Errorf("Expected the name of a service, see corectl -h")
+	}
+	// Parsing stops at the name, so the flags after it are parsed separately
+	name := flags.Arg(0)
+	_ = flags.Parse(flags.Args()[1:])
+	if flags.NArg() != 0 {
+		return fmt.Errorf("Expected only the name of a service, see corectl -h")
+	}
+
+	reply, err := requestExtension(&pb_core_extensions.CoreExtensionMessage{
+		Msg: &pb_core_extensions.CoreExtensionMessage_ServiceInstancesRequest{
+			ServiceInstancesRequest: &pb_core_extensions.ServiceInstancesRequest{
+				Name: name,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	instances := reply.GetServiceInstanceList()
+	if len(instances.GetInstances()) == 0 {
+		return fmt.Errorf("Service '%s' is not registered", name)
+	}
+	if *asJSON {
+		return printJSON(instances)
+	}
+
+	// Show the current value of every option next to its default
+	tuning, err := getTuningState()
+	if err != nil {
+		return err
+	}
+
+	for i, instance := range instances.GetInstances() {
+		s := instance.GetService()
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("Service:  %s\n", s.GetIdentifier().GetName())
+		if instance.GetInstance() != "" {
+			fmt.Printf("Instance: %s\n", instance.GetInstance())
+		}
+		fmt.Printf("PID:      %d\n", s.GetIdentifier().GetPid())
+		fmt.Printf("Status:   %s\n", formatStatus(s.GetStatus(), instance.GetExtendedStatus()))
+
+		table := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Println("\nEndpoints:")
+		fmt.Fprintln(table, "  NAME\tCONNECT\tBIND")
+		for _, e := range instance.GetEndpoints() {
+			fmt.Fprintf(table, "  %s\t%s\t%s\n", e.GetName(), e.GetConnectAddress(), e.GetBindAddress())
+		}
+		err = table.Flush()
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("\nOptions:")
+		fmt.Fprintln(table, "  NAME\tTYPE\tMUTABLE\tDEFAULT\tVALUE")
+		for _, o := range s.GetOptions() {
+			value := "-"
+			if p := findParameter(tuning, o.GetName()); p != nil {
+				value = formatParameterValue(p)
+			}
+			fmt.Fprintf(table, "  %s\t%s\t%s\t%s\t%s\n", o.GetName(), services.OptionTypeToString(o.GetType()), strconv.FormatBool(o.GetMutable()), formatOptionDefault(o), value)
+		}
+		err = table.Flush()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Formats a status along with the extended status that core knows about, if there is one
+func formatStatus(status pb_core_messages.ServiceStatus, extendedStatus pb_core_extensions.ExtendedServiceStatus) string {
+	if extendedStatus == pb_core_extensions.ExtendedServiceStatus_NO_EXTENDED_STATUS {
+		return status.String()
+	}
+	return fmt.Sprintf("%s (%s)", status.String(), extendedStatus.String())
+}
+
+func formatOptionDefault(option *pb_core_messages.ServiceOption) string {
+	switch option.GetType() {
+	case pb_core_messages.ServiceOption_INT:
+		return strconv.Itoa(int(option.GetIntDefault()))
+	case pb_core_messages.ServiceOption_FLOAT:
+		return strconv.FormatFloat(float64(option.GetFloatDefault()), 'g', -1, 32)
+	case pb_core_messages.ServiceOption_STRING:
+		return strconv.Quote(option.GetStringDefault())
+	default:
+		return "-"
+	}
+}
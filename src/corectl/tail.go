@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	pb_core_messages "github.com/VU-ASE/rovercom/packages/go/core"
+	zmq "github.com/pebbe/zmq4"
+)
+
+func tailBroadcasts(topics []string) error {
+	socket, err := zmq.NewSocket(zmq.SUB)
+	if err != nil {
+		return err
+	}
+	defer socket.Close()
+	err = socket.Connect(*broadcastAddr)
+	if err != nil {
+		return fmt.Errorf("Could not connect to core at %s: %v", *broadcastAddr, err)
+	}
+	if len(topics) == 0 {
+		topics = []string{""}
+	}
+	for _, topic := range topics {
+		err = socket.SetSubscribe(topic)
+		if err != nil {
+			return err
+		}
+	}
+
+	var last uint64
+	for {
+		frames, err := socket.RecvMessageBytes(0)
+		if err != nil {
+			return err
+		}
+		broadcast, err := pb_core_extensions.ParseBroadcast(frames)
+		if err != nil {
+			fmt.Printf("%s  could not parse broadcast: %v\n", time.Now().Format(time.TimeOnly), err)
+			continue
+		}
+
+		// Only a subscription to everything receives every sequence number
+		if last != 0 && broadcast.Sequence != last+1 && len(topics) == 1 && topics[0] == "" {
+			if broadcast.Sequence <= last {
+				fmt.Printf("%s  core restarted\n", time.Now().Format(time.TimeOnly))
+			} else {
+				fmt.Printf("%s  missed %d broadcast(s)\n", time.Now().Format(time.TimeOnly), broadcast.Sequence-last-1)
+			}
+		}
+		last = broadcast.Sequence
+
+		fmt.Printf("%s  #%-6d %-24s %s\n", time.Now().Format(time.TimeOnly), broadcast.Sequence, broadcast.Topic, describeBroadcast(broadcast.Message))
+	}
+}
+
+// Summarizes a broadcast message on a single line
+func describeBroadcast(message *pb_core_extensions.CoreExtensionMessage) string {
+	switch {
+	case message.GetService() != nil:
+		s := message.GetService()
+		return fmt.Sprintf("%s registered (pid %d, %s)", s.GetIdentifier().GetName(), s.GetIdentifier().GetPid(), s.GetStatus().String())
+	case message.GetServiceEvent() != nil:
+		event := message.GetServiceEvent()
+		s := event.GetService()
+		change := fmt.Sprintf("%s -> %s", event.GetPreviousStatus().String(), formatStatus(s.GetStatus(), event.GetExtendedStatus()))
+		if event.GetRemoved() {
+			change = "removed"
+		}
+		return fmt.Sprintf("%s (pid %d) %s: %s", s.GetIdentifier().GetName(), s.GetIdentifier().GetPid(), change, strings.ToLower(event.GetReason().String()))
+	case message.GetTuningState() != nil:
+		return describeTuningState(message.GetTuningState())
+	case message.GetTuningParameterUpdate() != nil:
+		update := message.GetTuningParameterUpdate()
+		if update.GetParameter() == nil {
+			return fmt.Sprintf("%s removed (revision %d)", update.GetKey(), update.GetRevision())
+		}
+		return fmt.Sprintf("%s=%s (revision %d)", update.GetKey(), formatParameterValue(update.GetParameter()), update.GetRevision())
+	default:
+		return fmt.Sprintf("unknown message %v", message)
+	}
+}
+
+func describeTuningState(tuning *pb_core_messages.TuningState) string {
+	if len(tuning.GetDynamicParameters()) == 0 {
+		return "no parameters"
+	}
+	parameters := make([]string, 0, len(tuning.GetDynamicParameters()))
+	for _, p := range tuning.GetDynamicParameters() {
+		parameters = append(parameters, fmt.Sprintf("%s=%s", parameterKey(p), formatParameterValue(p)))
+	}
+	return strings.Join(parameters, " ")
+}
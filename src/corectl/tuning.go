@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"vu/ase/core/src/services"
+
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	pb_core_messages "github.com/VU-ASE/rovercom/packages/go/core"
+)
+
+// Returns the parameter with this key from the tuning state, or nil if there is none
+func findParameter(tuning *pb_core_messages.TuningState, key string) *pb_core_messages.TuningState_Parameter {
+	for _, p := range tuning.GetDynamicParameters() {
+		if parameterKey(p) == key {
+			return p
+		}
+	}
+	return nil
+}
+
+func parameterKey(parameter *pb_core_messages.TuningState_Parameter) string {
+	switch {
+	case parameter.GetInt() != nil:
+		return parameter.GetInt().GetKey()
+	case parameter.GetFloat() != nil:
+		return parameter.GetFloat().GetKey()
+	case parameter.GetString_() != nil:
+		return parameter.GetString_().GetKey()
+	default:
+		return ""
+	}
+}
+
+func formatParameterValue(parameter *pb_core_messages.TuningState_Parameter) string {
+	switch {
+	case parameter.GetInt() != nil:
+		return strconv.FormatInt(parameter.GetInt().GetValue(), 10)
+	case parameter.GetFloat() != nil:
+		return strconv.FormatFloat(float64(parameter.GetFloat().GetValue()), 'g', -1, 32)
+	case parameter.GetString_() != nil:
+		return strconv.Quote(parameter.GetString_().GetValue())
+	default:
+		return "-"
+	}
+}
+
+func getParameter(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("Expected the key of a tuning parameter, see corectl -h")
+	}
+
+	tuning, err := getTuningState()
+	if err != nil {
+		return err
+	}
+	parameter := findParameter(tuning, args[0])
+	if parameter == nil {
+		return fmt.Errorf("Tuning state has no parameter '%s'", args[0])
+	}
+	fmt.Println(formatParameterValue(parameter))
+	return nil
+}
+
+// Parses the value into a tuning parameter of the type of the option that declares it
+func parseParameter(option *pb_core_messages.ServiceOption, value string) (*pb_core_messages.TuningState_Parameter, error) {
+	switch option.GetType() {
+	case pb_core_messages.ServiceOption_INT:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Option '%s' is an int, '%s' is not", option.GetName(), value)
+		}
+		return &pb_core_messages.TuningState_Parameter{
+			Parameter: &pb_core_messages.TuningState_Parameter_Int{
+				Int: &pb_core_messages.TuningState_Parameter_IntParameter{Key: option.GetName(), Value: parsed},
+			},
+		}, nil
+	case pb_core_messages.ServiceOption_FLOAT:
+		parsed, err := strconv.ParseFloat(value, 32)
+		if err != nil {
+			return nil, fmt.Errorf("Option '%s' is a float, '%s' is not", option.GetName(), value)
+		}
+		return &pb_core_messages.TuningState_Parameter{
+			Parameter: &pb_core_messages.TuningState_Parameter_Float{
+				Float: &pb_core_messages.TuningState_Parameter_FloatParameter{Key: option.GetName(), Value: float32(parsed)},
+			},
+		}, nil
+	case pb_core_messages.ServiceOption_STRING:
+		return &pb_core_messages.TuningState_Parameter{
+			Parameter: &pb_core_messages.TuningState_Parameter_String_{
+				String_: &pb_core_messages.TuningState_Parameter_StringParameter{Key: option.GetName(), Value: value},
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("Option '%s' has unknown type %s", option.GetName(), services.OptionTypeToString(option.GetType()))
+	}
+}
+
+func setParameter(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("Expected the key and value of a tuning parameter, see corectl -h")
+	}
+	key, value := args[0], args[1]
+
+	// The value is checked against the option before core is asked to change anything
+	list, err := getServices()
+	if err != nil {
+		return err
+	}
+	option, service := findOption(list, key)
+	if option == nil {
+		return fmt.Errorf("No registered service has an option '%s'", key)
+	}
+	if !option.GetMutable() {
+		return fmt.Errorf("Option '%s' of service '%s' is not mutable", key, service.GetIdentifier().GetName())
+	}
+	parameter, err := parseParameter(option, value)
+	if err != nil {
+		return err
+	}
+
+	// Only this parameter is changed, all others keep their current values
+	reply, err := requestExtension(&pb_core_extensions.CoreExtensionMessage{
+		Msg: &pb_core_extensions.CoreExtensionMessage_TuningStateUpsert{
+			TuningStateUpsert: &pb_core_extensions.TuningStateUpsert{
+				Mode: pb_core_extensions.TuningStateUpsert_MERGE,
+				State: &pb_core_messages.TuningState{
+					DynamicParameters: []*pb_core_messages.TuningState_Parameter{parameter},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	revision := reply.GetTuningRevision()
+	updated := findParameter(revision.GetState(), key)
+	if updated == nil {
+		return fmt.Errorf("Core accepted the change, but the tuning state has no parameter '%s'", key)
+	}
+	fmt.Printf("%s = %s (revision %d)\n", key, formatParameterValue(updated), revision.GetRevision())
+	return nil
+}
@@ -0,0 +1,71 @@
+package pb_core_extensions
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Every broadcast on the pub/sub socket of core is a multipart message of three frames: the topic, the sequence number
+// (a big-endian uint64 that increases by one for every broadcast, starting at 1 when core starts) and a CoreExtensionMessage.
+// Registrations and tuning states are sent as the Service and TuningState messages, which clients that only know CoreMessage
+// can read as well. Status changes and removals are sent as ServiceEvents, and changes to a single tuning parameter as
+// TuningParameterUpdates, which such clients skip.
+// A gap in the sequence numbers means that broadcasts were lost, and a lower sequence number means that core restarted.
+// In both cases, a SnapshotRequest returns the current state.
+// ZeroMQ subscriptions match on a prefix of the topic, so a subscriber can choose what it receives:
+//
+//	""               everything
+//	"service/"       every change to any service
+//	"service/<name>" every change to the service with this name (registration, status changes and removal)
+//	"status"         only status changes of registered services, including their removal
+//	"tuning"         the full tuning state after every change, as well as the per-key messages below
+//	"tuning/<key>"   the new value of the parameter with this key, or none if it was removed
+//
+// A status change is sent under both its service topic and the status topic, and a tuning change under the tuning topic
+// and the topic of every changed key, so subscribers to overlapping prefixes receive it more than once
+const (
+	TopicServicePrefix   = "service/"
+	TopicStatus          = "status"
+	TopicTuning          = "tuning"
+	TopicTuningKeyPrefix = TopicTuning + "/"
+)
+
+// Returns the topic under which changes to the service with this name are broadcast
+func ServiceTopic(name string) string {
+	return TopicServicePrefix + name
+}
+
+// Returns the topic under which changes to the tuning parameter with this key are broadcast
+func TuningKeyTopic(key string) string {
+	return TopicTuningKeyPrefix + key
+}
+
+// A broadcast as received on the pub/sub socket of core
+type Broadcast struct {
+	Topic    string
+	Sequence uint64
+	Message  *CoreExtensionMessage
+}
+
+// Parses the frames of a broadcast, as received with RecvMessageBytes
+func ParseBroadcast(frames [][]byte) (*Broadcast, error) {
+	if len(frames) != 3 {
+		return nil, fmt.Errorf("Broadcast has %d frames instead of 3 (topic, sequence number and message)", len(frames))
+	}
+	if len(frames[1]) != 8 {
+		return nil, fmt.Errorf("Broadcast has a sequence number of %d bytes instead of 8", len(frames[1]))
+	}
+
+	message := &CoreExtensionMessage{}
+	err := proto.Unmarshal(frames[2], message)
+	if err != nil {
+		return nil, err
+	}
+	return &Broadcast{
+		Topic:    string(frames[0]),
+		Sequence: binary.BigEndian.Uint64(frames[1]),
+		Message:  message,
+	}, nil
+}
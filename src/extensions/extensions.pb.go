@@ -0,0 +1,3216 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: extensions.proto
+
+package pb_core_extensions
+
+import (
+	core "github.com/VU-ASE/rovercom/packages/go/core"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// What core knows about a service on top of its protobuf_msgs.ServiceStatus, which only ever holds the values that rovercom defines
+type ExtendedServiceStatus int32
+
+const (
+	ExtendedServiceStatus_NO_EXTENDED_STATUS ExtendedServiceStatus = 0
+	ExtendedServiceStatus_CRASH_LOOPING      ExtendedServiceStatus = 1 // the service exited too often in a short time, core gave up on restarting it. Its status is STOPPED
+	ExtendedServiceStatus_UNRESPONSIVE       ExtendedServiceStatus = 2 // the service did not renew its lease in time. Its status is UNKNOWN until it does
+)
+
+// Enum value maps for ExtendedServiceStatus.
+var (
+	ExtendedServiceStatus_name = map[int32]string{
+		0: "NO_EXTENDED_STATUS",
+		1: "CRASH_LOOPING",
+		2: "UNRESPONSIVE",
+	}
+	ExtendedServiceStatus_value = map[string]int32{
+		"NO_EXTENDED_STATUS": 0,
+		"CRASH_LOOPING":      1,
+		"UNRESPONSIVE":       2,
+	}
+)
+
+func (x ExtendedServiceStatus) Enum() *ExtendedServiceStatus {
+	p := new(ExtendedServiceStatus)
+	*p = x
+	return p
+}
+
+func (x ExtendedServiceStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExtendedServiceStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_extensions_proto_enumTypes[0].Descriptor()
+}
+
+func (ExtendedServiceStatus) Type() protoreflect.EnumType {
+	return &file_extensions_proto_enumTypes[0]
+}
+
+func (x ExtendedServiceStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExtendedServiceStatus.Descriptor instead.
+func (ExtendedServiceStatus) EnumDescriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{0}
+}
+
+type TuningStateUpsert_Mode int32
+
+const (
+	TuningStateUpsert_REPLACE TuningStateUpsert_Mode = 0 // replace the entire tuning state, like a plain TuningState upsert does
+	TuningStateUpsert_MERGE   TuningStateUpsert_Mode = 1 // only change the parameters in this upsert, all other parameters keep their current values
+)
+
+// Enum value maps for TuningStateUpsert_Mode.
+var (
+	TuningStateUpsert_Mode_name = map[int32]string{
+		0: "REPLACE",
+		1: "MERGE",
+	}
+	TuningStateUpsert_Mode_value = map[string]int32{
+		"REPLACE": 0,
+		"MERGE":   1,
+	}
+)
+
+func (x TuningStateUpsert_Mode) Enum() *TuningStateUpsert_Mode {
+	p := new(TuningStateUpsert_Mode)
+	*p = x
+	return p
+}
+
+func (x TuningStateUpsert_Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TuningStateUpsert_Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_extensions_proto_enumTypes[1].Descriptor()
+}
+
+func (TuningStateUpsert_Mode) Type() protoreflect.EnumType {
+	return &file_extensions_proto_enumTypes[1]
+}
+
+func (x TuningStateUpsert_Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TuningStateUpsert_Mode.Descriptor instead.
+func (TuningStateUpsert_Mode) EnumDescriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{13, 0}
+}
+
+type ServiceEvent_Reason int32
+
+const (
+	ServiceEvent_REPORTED      ServiceEvent_Reason = 0 // the service reported its new status itself
+	ServiceEvent_PROCESS_GONE  ServiceEvent_Reason = 1 // the process of the service no longer exists
+	ServiceEvent_EXPLICIT_STOP ServiceEvent_Reason = 2 // the service deregistered, or was stopped by a service order
+	ServiceEvent_LEASE_EXPIRED ServiceEvent_Reason = 3 // the service did not renew its lease in time, it is removed once it has not done so for too long
+	ServiceEvent_LEASE_RENEWED ServiceEvent_Reason = 4 // the service renewed its expired lease
+	ServiceEvent_CRASH_LOOPING ServiceEvent_Reason = 5 // core gave up on restarting the process of the service
+	ServiceEvent_REPLACED      ServiceEvent_Reason = 6 // the service was no longer alive, and a new registration of the same service took its place
+)
+
+// Enum value maps for ServiceEvent_Reason.
+var (
+	ServiceEvent_Reason_name = map[int32]string{
+		0: "REPORTED",
+		1: "PROCESS_GONE",
+		2: "EXPLICIT_STOP",
+		3: "LEASE_EXPIRED",
+		4: "LEASE_RENEWED",
+		5: "CRASH_LOOPING",
+		6: "REPLACED",
+	}
+	ServiceEvent_Reason_value = map[string]int32{
+		"REPORTED":      0,
+		"PROCESS_GONE":  1,
+		"EXPLICIT_STOP": 2,
+		"LEASE_EXPIRED": 3,
+		"LEASE_RENEWED": 4,
+		"CRASH_LOOPING": 5,
+		"REPLACED":      6,
+	}
+)
+
+func (x ServiceEvent_Reason) Enum() *ServiceEvent_Reason {
+	p := new(ServiceEvent_Reason)
+	*p = x
+	return p
+}
+
+func (x ServiceEvent_Reason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ServiceEvent_Reason) Descriptor() protoreflect.EnumDescriptor {
+	return file_extensions_proto_enumTypes[2].Descriptor()
+}
+
+func (ServiceEvent_Reason) Type() protoreflect.EnumType {
+	return &file_extensions_proto_enumTypes[2]
+}
+
+func (x ServiceEvent_Reason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ServiceEvent_Reason.Descriptor instead.
+func (ServiceEvent_Reason) EnumDescriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{28, 0}
+}
+
+type CoreExtensionMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Msg:
+	//	*CoreExtensionMessage_Service
+	//	*CoreExtensionMessage_TuningState
+	//	*CoreExtensionMessage_Error
+	//	*CoreExtensionMessage_TuningRevisionListRequest
+	//	*CoreExtensionMessage_TuningRevisionList
+	//	*CoreExtensionMessage_TuningRevisionDiffRequest
+	//	*CoreExtensionMessage_TuningRevisionDiff
+	//	*CoreExtensionMessage_TuningRollbackRequest
+	//	*CoreExtensionMessage_ServiceRegistration
+	//	*CoreExtensionMessage_TuningStateUpsert
+	//	*CoreExtensionMessage_TuningRevision
+	//	*CoreExtensionMessage_AuthenticatedStatusUpdate
+	//	*CoreExtensionMessage_ServiceDeregistration
+	//	*CoreExtensionMessage_Heartbeat
+	//	*CoreExtensionMessage_Lease
+	//	*CoreExtensionMessage_ServiceInstancesRequest
+	//	*CoreExtensionMessage_ServiceInstanceList
+	//	*CoreExtensionMessage_SnapshotRequest
+	//	*CoreExtensionMessage_Snapshot
+	//	*CoreExtensionMessage_ServiceEvent
+	//	*CoreExtensionMessage_TuningAuditRequest
+	//	*CoreExtensionMessage_TuningAuditTrail
+	//	*CoreExtensionMessage_TuningParameterUpdate
+	//	*CoreExtensionMessage_AuthenticatedServiceOrder
+	Msg isCoreExtensionMessage_Msg `protobuf_oneof:"msg"`
+}
+
+func (x *CoreExtensionMessage) Reset() {
+	*x = CoreExtensionMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CoreExtensionMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoreExtensionMessage) ProtoMessage() {}
+
+func (x *CoreExtensionMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoreExtensionMessage.ProtoReflect.Descriptor instead.
+func (*CoreExtensionMessage) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *CoreExtensionMessage) GetMsg() isCoreExtensionMessage_Msg {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetService() *core.Service {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_Service); ok {
+		return x.Service
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetTuningState() *core.TuningState {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_TuningState); ok {
+		return x.TuningState
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetError() *DetailedError {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetTuningRevisionListRequest() *TuningRevisionListRequest {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_TuningRevisionListRequest); ok {
+		return x.TuningRevisionListRequest
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetTuningRevisionList() *TuningRevisionList {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_TuningRevisionList); ok {
+		return x.TuningRevisionList
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetTuningRevisionDiffRequest() *TuningRevisionDiffRequest {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_TuningRevisionDiffRequest); ok {
+		return x.TuningRevisionDiffRequest
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetTuningRevisionDiff() *TuningRevisionDiff {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_TuningRevisionDiff); ok {
+		return x.TuningRevisionDiff
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetTuningRollbackRequest() *TuningRollbackRequest {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_TuningRollbackRequest); ok {
+		return x.TuningRollbackRequest
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetServiceRegistration() *ServiceRegistration {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_ServiceRegistration); ok {
+		return x.ServiceRegistration
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetTuningStateUpsert() *TuningStateUpsert {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_TuningStateUpsert); ok {
+		return x.TuningStateUpsert
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetTuningRevision() *TuningRevision {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_TuningRevision); ok {
+		return x.TuningRevision
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetAuthenticatedStatusUpdate() *AuthenticatedStatusUpdate {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_AuthenticatedStatusUpdate); ok {
+		return x.AuthenticatedStatusUpdate
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetServiceDeregistration() *ServiceDeregistration {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_ServiceDeregistration); ok {
+		return x.ServiceDeregistration
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetHeartbeat() *Heartbeat {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_Heartbeat); ok {
+		return x.Heartbeat
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetLease() *Lease {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_Lease); ok {
+		return x.Lease
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetServiceInstancesRequest() *ServiceInstancesRequest {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_ServiceInstancesRequest); ok {
+		return x.ServiceInstancesRequest
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetServiceInstanceList() *ServiceInstanceList {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_ServiceInstanceList); ok {
+		return x.ServiceInstanceList
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetSnapshotRequest() *SnapshotRequest {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_SnapshotRequest); ok {
+		return x.SnapshotRequest
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetSnapshot() *Snapshot {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_Snapshot); ok {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetServiceEvent() *ServiceEvent {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_ServiceEvent); ok {
+		return x.ServiceEvent
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetTuningAuditRequest() *TuningAuditRequest {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_TuningAuditRequest); ok {
+		return x.TuningAuditRequest
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetTuningAuditTrail() *TuningAuditTrail {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_TuningAuditTrail); ok {
+		return x.TuningAuditTrail
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetTuningParameterUpdate() *TuningParameterUpdate {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_TuningParameterUpdate); ok {
+		return x.TuningParameterUpdate
+	}
+	return nil
+}
+
+func (x *CoreExtensionMessage) GetAuthenticatedServiceOrder() *AuthenticatedServiceOrder {
+	if x, ok := x.GetMsg().(*CoreExtensionMessage_AuthenticatedServiceOrder); ok {
+		return x.AuthenticatedServiceOrder
+	}
+	return nil
+}
+
+type isCoreExtensionMessage_Msg interface {
+	isCoreExtensionMessage_Msg()
+}
+
+type CoreExtensionMessage_Service struct {
+	Service *core.Service `protobuf:"bytes,1,opt,name=service,proto3,oneof"`
+}
+
+type CoreExtensionMessage_TuningState struct {
+	TuningState *core.TuningState `protobuf:"bytes,5,opt,name=tuningState,proto3,oneof"`
+}
+
+type CoreExtensionMessage_Error struct {
+	Error *DetailedError `protobuf:"bytes,11,opt,name=error,proto3,oneof"`
+}
+
+type CoreExtensionMessage_TuningRevisionListRequest struct {
+	TuningRevisionListRequest *TuningRevisionListRequest `protobuf:"bytes,100,opt,name=tuningRevisionListRequest,proto3,oneof"`
+}
+
+type CoreExtensionMessage_TuningRevisionList struct {
+	TuningRevisionList *TuningRevisionList `protobuf:"bytes,101,opt,name=tuningRevisionList,proto3,oneof"`
+}
+
+type CoreExtensionMessage_TuningRevisionDiffRequest struct {
+	TuningRevisionDiffRequest *TuningRevisionDiffRequest `protobuf:"bytes,102,opt,name=tuningRevisionDiffRequest,proto3,oneof"`
+}
+
+type CoreExtensionMessage_TuningRevisionDiff struct {
+	TuningRevisionDiff *TuningRevisionDiff `protobuf:"bytes,103,opt,name=tuningRevisionDiff,proto3,oneof"`
+}
+
+type CoreExtensionMessage_TuningRollbackRequest struct {
+	TuningRollbackRequest *TuningRollbackRequest `protobuf:"bytes,104,opt,name=tuningRollbackRequest,proto3,oneof"`
+}
+
+type CoreExtensionMessage_ServiceRegistration struct {
+	ServiceRegistration *ServiceRegistration `protobuf:"bytes,105,opt,name=serviceRegistration,proto3,oneof"`
+}
+
+type CoreExtensionMessage_TuningStateUpsert struct {
+	TuningStateUpsert *TuningStateUpsert `protobuf:"bytes,106,opt,name=tuningStateUpsert,proto3,oneof"`
+}
+
+type CoreExtensionMessage_TuningRevision struct {
+	TuningRevision *TuningRevision `protobuf:"bytes,107,opt,name=tuningRevision,proto3,oneof"`
+}
+
+type CoreExtensionMessage_AuthenticatedStatusUpdate struct {
+	AuthenticatedStatusUpdate *AuthenticatedStatusUpdate `protobuf:"bytes,108,opt,name=authenticatedStatusUpdate,proto3,oneof"`
+}
+
+type CoreExtensionMessage_ServiceDeregistration struct {
+	ServiceDeregistration *ServiceDeregistration `protobuf:"bytes,109,opt,name=serviceDeregistration,proto3,oneof"`
+}
+
+type CoreExtensionMessage_Heartbeat struct {
+	Heartbeat *Heartbeat `protobuf:"bytes,110,opt,name=heartbeat,proto3,oneof"`
+}
+
+type CoreExtensionMessage_Lease struct {
+	Lease *Lease `protobuf:"bytes,111,opt,name=lease,proto3,oneof"`
+}
+
+type CoreExtensionMessage_ServiceInstancesRequest struct {
+	ServiceInstancesRequest *ServiceInstancesRequest `protobuf:"bytes,112,opt,name=serviceInstancesRequest,proto3,oneof"`
+}
+
+type CoreExtensionMessage_ServiceInstanceList struct {
+	ServiceInstanceList *ServiceInstanceList `protobuf:"bytes,113,opt,name=serviceInstanceList,proto3,oneof"`
+}
+
+type CoreExtensionMessage_SnapshotRequest struct {
+	SnapshotRequest *SnapshotRequest `protobuf:"bytes,114,opt,name=snapshotRequest,proto3,oneof"`
+}
+
+type CoreExtensionMessage_Snapshot struct {
+	Snapshot *Snapshot `protobuf:"bytes,115,opt,name=snapshot,proto3,oneof"`
+}
+
+type CoreExtensionMessage_ServiceEvent struct {
+	ServiceEvent *ServiceEvent `protobuf:"bytes,116,opt,name=serviceEvent,proto3,oneof"`
+}
+
+type CoreExtensionMessage_TuningAuditRequest struct {
+	TuningAuditRequest *TuningAuditRequest `protobuf:"bytes,117,opt,name=tuningAuditRequest,proto3,oneof"`
+}
+
+type CoreExtensionMessage_TuningAuditTrail struct {
+	TuningAuditTrail *TuningAuditTrail `protobuf:"bytes,118,opt,name=tuningAuditTrail,proto3,oneof"`
+}
+
+type CoreExtensionMessage_TuningParameterUpdate struct {
+	TuningParameterUpdate *TuningParameterUpdate `protobuf:"bytes,119,opt,name=tuningParameterUpdate,proto3,oneof"`
+}
+
+type CoreExtensionMessage_AuthenticatedServiceOrder struct {
+	AuthenticatedServiceOrder *AuthenticatedServiceOrder `protobuf:"bytes,120,opt,name=authenticatedServiceOrder,proto3,oneof"`
+}
+
+func (*CoreExtensionMessage_Service) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_TuningState) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_Error) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_TuningRevisionListRequest) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_TuningRevisionList) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_TuningRevisionDiffRequest) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_TuningRevisionDiff) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_TuningRollbackRequest) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_ServiceRegistration) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_TuningStateUpsert) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_TuningRevision) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_AuthenticatedStatusUpdate) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_ServiceDeregistration) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_Heartbeat) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_Lease) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_ServiceInstancesRequest) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_ServiceInstanceList) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_SnapshotRequest) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_Snapshot) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_ServiceEvent) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_TuningAuditRequest) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_TuningAuditTrail) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_TuningParameterUpdate) isCoreExtensionMessage_Msg() {}
+
+func (*CoreExtensionMessage_AuthenticatedServiceOrder) isCoreExtensionMessage_Msg() {}
+
+// Wire compatible with protobuf_msgs.Error, so clients that only know CoreMessage can still read the message,
+// while clients that know about the extensions can read the details
+type DetailedError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message          string             `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	TuningViolations []*TuningViolation `protobuf:"bytes,2,rep,name=tuningViolations,proto3" json:"tuningViolations,omitempty"` // set when a tuning state upsert was rejected
+	CurrentTuning    *TuningRevision    `protobuf:"bytes,3,opt,name=currentTuning,proto3" json:"currentTuning,omitempty"`       // set when a tuning state upsert was based on an outdated revision, so the client can rebase
+}
+
+func (x *DetailedError) Reset() {
+	*x = DetailedError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DetailedError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetailedError) ProtoMessage() {}
+
+func (x *DetailedError) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetailedError.ProtoReflect.Descriptor instead.
+func (*DetailedError) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DetailedError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DetailedError) GetTuningViolations() []*TuningViolation {
+	if x != nil {
+		return x.TuningViolations
+	}
+	return nil
+}
+
+func (x *DetailedError) GetCurrentTuning() *TuningRevision {
+	if x != nil {
+		return x.CurrentTuning
+	}
+	return nil
+}
+
+// Registers a service, just like sending a plain Service does, but with the extra information that core supports.
+// Core replies with the same message, containing the service as it was registered and the token of its session
+type ServiceRegistration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Service     *core.Service              `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Constraints []*ServiceOptionConstraint `protobuf:"bytes,2,rep,name=constraints,proto3" json:"constraints,omitempty"`
+	// set by core in its reply. Every later request that changes the service (status updates, deregistrations and orders) must
+	// present this token. Services that register with a plain Service get no token, so any client can change those
+	Token string `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	// if set, the service is considered alive for as long as it renews this lease with heartbeats, instead of for as long as its pid exists.
+	// In its reply, core sets this to the lease duration that it granted (in milliseconds)
+	LeaseDuration uint64 `protobuf:"varint,4,opt,name=leaseDuration,proto3" json:"leaseDuration,omitempty"`
+	// if set, other instances of the same service (with other instance IDs) can be registered at the same time
+	Instance string `protobuf:"bytes,5,opt,name=instance,proto3" json:"instance,omitempty"`
+	// set by core in its reply. The addresses in the service are replaced by the connect form
+	Endpoints []*EndpointAddresses `protobuf:"bytes,6,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	// the names of the outputs that core should assign a port to (from its port range). The service binds to the bind address
+	// of the corresponding endpoint in the reply
+	AllocateOutputs []string `protobuf:"bytes,7,rep,name=allocateOutputs,proto3" json:"allocateOutputs,omitempty"`
+}
+
+func (x *ServiceRegistration) Reset() {
+	*x = ServiceRegistration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServiceRegistration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceRegistration) ProtoMessage() {}
+
+func (x *ServiceRegistration) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceRegistration.ProtoReflect.Descriptor instead.
+func (*ServiceRegistration) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ServiceRegistration) GetService() *core.Service {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+func (x *ServiceRegistration) GetConstraints() []*ServiceOptionConstraint {
+	if x != nil {
+		return x.Constraints
+	}
+	return nil
+}
+
+func (x *ServiceRegistration) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ServiceRegistration) GetLeaseDuration() uint64 {
+	if x != nil {
+		return x.LeaseDuration
+	}
+	return 0
+}
+
+func (x *ServiceRegistration) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *ServiceRegistration) GetEndpoints() []*EndpointAddresses {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+func (x *ServiceRegistration) GetAllocateOutputs() []string {
+	if x != nil {
+		return x.AllocateOutputs
+	}
+	return nil
+}
+
+// A service binds to an address such as tcp://*:1337, which clients cannot connect to. Core resolves it into a connectable address
+// using the interfaces of its host (or the host it was told to advertise)
+type EndpointAddresses struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name           string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	BindAddress    string `protobuf:"bytes,2,opt,name=bindAddress,proto3" json:"bindAddress,omitempty"`
+	ConnectAddress string `protobuf:"bytes,3,opt,name=connectAddress,proto3" json:"connectAddress,omitempty"`
+}
+
+func (x *EndpointAddresses) Reset() {
+	*x = EndpointAddresses{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EndpointAddresses) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndpointAddresses) ProtoMessage() {}
+
+func (x *EndpointAddresses) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndpointAddresses.ProtoReflect.Descriptor instead.
+func (*EndpointAddresses) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EndpointAddresses) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EndpointAddresses) GetBindAddress() string {
+	if x != nil {
+		return x.BindAddress
+	}
+	return ""
+}
+
+func (x *EndpointAddresses) GetConnectAddress() string {
+	if x != nil {
+		return x.ConnectAddress
+	}
+	return ""
+}
+
+// Asks for the registered instances of a service. Core replies with a ServiceInstanceList
+type ServiceInstancesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Instance string `protobuf:"bytes,2,opt,name=instance,proto3" json:"instance,omitempty"` // if set, only this instance is returned (or an error if it is not registered)
+}
+
+func (x *ServiceInstancesRequest) Reset() {
+	*x = ServiceInstancesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServiceInstancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceInstancesRequest) ProtoMessage() {}
+
+func (x *ServiceInstancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceInstancesRequest.ProtoReflect.Descriptor instead.
+func (*ServiceInstancesRequest) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ServiceInstancesRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ServiceInstancesRequest) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+type ServiceInstance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Service        *core.Service         `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Instance       string                `protobuf:"bytes,2,opt,name=instance,proto3" json:"instance,omitempty"` // empty if the service registered without an instance ID
+	Endpoints      []*EndpointAddresses  `protobuf:"bytes,3,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	ExtendedStatus ExtendedServiceStatus `protobuf:"varint,4,opt,name=extendedStatus,proto3,enum=core_extensions.ExtendedServiceStatus" json:"extendedStatus,omitempty"`
+}
+
+func (x *ServiceInstance) Reset() {
+	*x = ServiceInstance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServiceInstance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceInstance) ProtoMessage() {}
+
+func (x *ServiceInstance) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceInstance.ProtoReflect.Descriptor instead.
+func (*ServiceInstance) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ServiceInstance) GetService() *core.Service {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+func (x *ServiceInstance) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *ServiceInstance) GetEndpoints() []*EndpointAddresses {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+func (x *ServiceInstance) GetExtendedStatus() ExtendedServiceStatus {
+	if x != nil {
+		return x.ExtendedStatus
+	}
+	return ExtendedServiceStatus_NO_EXTENDED_STATUS
+}
+
+type ServiceInstanceList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Instances []*ServiceInstance `protobuf:"bytes,1,rep,name=instances,proto3" json:"instances,omitempty"`
+}
+
+func (x *ServiceInstanceList) Reset() {
+	*x = ServiceInstanceList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServiceInstanceList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceInstanceList) ProtoMessage() {}
+
+func (x *ServiceInstanceList) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceInstanceList.ProtoReflect.Descriptor instead.
+func (*ServiceInstanceList) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ServiceInstanceList) GetInstances() []*ServiceInstance {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+// Renews the lease of a service that registered with a lease duration. Core replies with the renewed lease
+type Heartbeat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Service *core.ServiceIdentifier `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Token   string                  `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // must be set if the service registered with a ServiceRegistration
+}
+
+func (x *Heartbeat) Reset() {
+	*x = Heartbeat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Heartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Heartbeat) ProtoMessage() {}
+
+func (x *Heartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Heartbeat.ProtoReflect.Descriptor instead.
+func (*Heartbeat) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Heartbeat) GetService() *core.ServiceIdentifier {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+func (x *Heartbeat) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type Lease struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Service   *core.ServiceIdentifier `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Duration  uint64                  `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`   // in milliseconds
+	ExpiresAt int64                   `protobuf:"varint,3,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"` // in milliseconds since epoch
+}
+
+func (x *Lease) Reset() {
+	*x = Lease{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Lease) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lease) ProtoMessage() {}
+
+func (x *Lease) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lease.ProtoReflect.Descriptor instead.
+func (*Lease) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Lease) GetService() *core.ServiceIdentifier {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+func (x *Lease) GetDuration() uint64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *Lease) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+// Changes the status of a service that registered with a ServiceRegistration. Core replies with the updated service
+type AuthenticatedStatusUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Update *core.ServiceStatusUpdate `protobuf:"bytes,1,opt,name=update,proto3" json:"update,omitempty"`
+	Token  string                    `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (x *AuthenticatedStatusUpdate) Reset() {
+	*x = AuthenticatedStatusUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuthenticatedStatusUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthenticatedStatusUpdate) ProtoMessage() {}
+
+func (x *AuthenticatedStatusUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthenticatedStatusUpdate.ProtoReflect.Descriptor instead.
+func (*AuthenticatedStatusUpdate) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AuthenticatedStatusUpdate) GetUpdate() *core.ServiceStatusUpdate {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *AuthenticatedStatusUpdate) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Gives an order to a service that registered with a ServiceRegistration, which does not accept plain ServiceOrders.
+// Core replies with the Service, just like it does for a plain ServiceOrder
+type AuthenticatedServiceOrder struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Order *core.ServiceOrder `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Token string             `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (x *AuthenticatedServiceOrder) Reset() {
+	*x = AuthenticatedServiceOrder{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuthenticatedServiceOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthenticatedServiceOrder) ProtoMessage() {}
+
+func (x *AuthenticatedServiceOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthenticatedServiceOrder.ProtoReflect.Descriptor instead.
+func (*AuthenticatedServiceOrder) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AuthenticatedServiceOrder) GetOrder() *core.ServiceOrder {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *AuthenticatedServiceOrder) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Removes a service from the registry. Core replies with the removed service
+type ServiceDeregistration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Service *core.ServiceIdentifier `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Token   string                  `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // must be set if the service registered with a ServiceRegistration
+}
+
+func (x *ServiceDeregistration) Reset() {
+	*x = ServiceDeregistration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServiceDeregistration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceDeregistration) ProtoMessage() {}
+
+func (x *ServiceDeregistration) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceDeregistration.ProtoReflect.Descriptor instead.
+func (*ServiceDeregistration) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ServiceDeregistration) GetService() *core.ServiceIdentifier {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+func (x *ServiceDeregistration) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Restricts the values that a tuning parameter can be set to. The numeric fields apply to int and float options,
+// the allowed values apply to string options
+type ServiceOptionConstraint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Option  string   `protobuf:"bytes,1,opt,name=option,proto3" json:"option,omitempty"`     // the name of the ServiceOption this constraint applies to
+	Min     *float64 `protobuf:"fixed64,2,opt,name=min,proto3,oneof" json:"min,omitempty"`   // inclusive
+	Max     *float64 `protobuf:"fixed64,3,opt,name=max,proto3,oneof" json:"max,omitempty"`   // inclusive
+	Step    *float64 `protobuf:"fixed64,4,opt,name=step,proto3,oneof" json:"step,omitempty"` // the value must be a whole number of steps away from min (or from 0 if there is no min)
+	Allowed []string `protobuf:"bytes,5,rep,name=allowed,proto3" json:"allowed,omitempty"`   // if not empty, the value must be one of these
+}
+
+func (x *ServiceOptionConstraint) Reset() {
+	*x = ServiceOptionConstraint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServiceOptionConstraint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceOptionConstraint) ProtoMessage() {}
+
+func (x *ServiceOptionConstraint) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceOptionConstraint.ProtoReflect.Descriptor instead.
+func (*ServiceOptionConstraint) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ServiceOptionConstraint) GetOption() string {
+	if x != nil {
+		return x.Option
+	}
+	return ""
+}
+
+func (x *ServiceOptionConstraint) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *ServiceOptionConstraint) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+func (x *ServiceOptionConstraint) GetStep() float64 {
+	if x != nil && x.Step != nil {
+		return *x.Step
+	}
+	return 0
+}
+
+func (x *ServiceOptionConstraint) GetAllowed() []string {
+	if x != nil {
+		return x.Allowed
+	}
+	return nil
+}
+
+// Changes the tuning state, with more control than a plain TuningState upsert (which always replaces the entire state).
+// Core broadcasts the resulting tuning state and replies with the new TuningRevision
+type TuningStateUpsert struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mode  TuningStateUpsert_Mode `protobuf:"varint,1,opt,name=mode,proto3,enum=core_extensions.TuningStateUpsert_Mode" json:"mode,omitempty"`
+	State *core.TuningState      `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	// only in MERGE mode: the parameters to remove from the tuning state. Options of registered services fall back to their default value
+	DeleteKeys []string `protobuf:"bytes,3,rep,name=deleteKeys,proto3" json:"deleteKeys,omitempty"`
+	// the revision that this upsert is based on. If set, core rejects the upsert when the tuning state has changed since
+	BaseRevision *uint64 `protobuf:"varint,4,opt,name=baseRevision,proto3,oneof" json:"baseRevision,omitempty"`
+}
+
+func (x *TuningStateUpsert) Reset() {
+	*x = TuningStateUpsert{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TuningStateUpsert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningStateUpsert) ProtoMessage() {}
+
+func (x *TuningStateUpsert) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuningStateUpsert.ProtoReflect.Descriptor instead.
+func (*TuningStateUpsert) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TuningStateUpsert) GetMode() TuningStateUpsert_Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return TuningStateUpsert_REPLACE
+}
+
+func (x *TuningStateUpsert) GetState() *core.TuningState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *TuningStateUpsert) GetDeleteKeys() []string {
+	if x != nil {
+		return x.DeleteKeys
+	}
+	return nil
+}
+
+func (x *TuningStateUpsert) GetBaseRevision() uint64 {
+	if x != nil && x.BaseRevision != nil {
+		return *x.BaseRevision
+	}
+	return 0
+}
+
+// A tuning parameter that could not be accepted, and why
+type TuningViolation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key    string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *TuningViolation) Reset() {
+	*x = TuningViolation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TuningViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningViolation) ProtoMessage() {}
+
+func (x *TuningViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuningViolation.ProtoReflect.Descriptor instead.
+func (*TuningViolation) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TuningViolation) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TuningViolation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// A single change to the tuning state, as kept in the tuning history
+type TuningRevision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Revision    uint64            `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`      // increases by one for every change. When core keeps its tuning state in a file, it continues from there after a restart
+	Timestamp   uint64            `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`    // the timestamp of the tuning state after this change
+	ChangedKeys []string          `protobuf:"bytes,3,rep,name=changedKeys,proto3" json:"changedKeys,omitempty"` // the keys of the parameters that were added, removed or changed
+	State       *core.TuningState `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`             // the tuning state after this change, omitted when listing revisions
+}
+
+func (x *TuningRevision) Reset() {
+	*x = TuningRevision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TuningRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningRevision) ProtoMessage() {}
+
+func (x *TuningRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuningRevision.ProtoReflect.Descriptor instead.
+func (*TuningRevision) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TuningRevision) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *TuningRevision) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *TuningRevision) GetChangedKeys() []string {
+	if x != nil {
+		return x.ChangedKeys
+	}
+	return nil
+}
+
+func (x *TuningRevision) GetState() *core.TuningState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+// The change of a single tuning parameter between two tuning states
+type TuningParameterChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string                      `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Old *core.TuningState_Parameter `protobuf:"bytes,2,opt,name=old,proto3" json:"old,omitempty"` // not set if the parameter was added
+	New *core.TuningState_Parameter `protobuf:"bytes,3,opt,name=new,proto3" json:"new,omitempty"` // not set if the parameter was removed
+}
+
+func (x *TuningParameterChange) Reset() {
+	*x = TuningParameterChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TuningParameterChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningParameterChange) ProtoMessage() {}
+
+func (x *TuningParameterChange) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuningParameterChange.ProtoReflect.Descriptor instead.
+func (*TuningParameterChange) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TuningParameterChange) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TuningParameterChange) GetOld() *core.TuningState_Parameter {
+	if x != nil {
+		return x.Old
+	}
+	return nil
+}
+
+func (x *TuningParameterChange) GetNew() *core.TuningState_Parameter {
+	if x != nil {
+		return x.New
+	}
+	return nil
+}
+
+// Broadcast under the topic of a tuning parameter when it changes. It is not a TuningState, so that subscribers that
+// read every broadcast as a CoreMessage skip it rather than taking it for the full tuning state
+type TuningParameterUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key       string                      `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Parameter *core.TuningState_Parameter `protobuf:"bytes,2,opt,name=parameter,proto3" json:"parameter,omitempty"`  // the new value, not set if the parameter was removed
+	Revision  uint64                      `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`   // the tuning revision that made the change
+	Timestamp uint64                      `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // the timestamp of the tuning state after the change
+}
+
+func (x *TuningParameterUpdate) Reset() {
+	*x = TuningParameterUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TuningParameterUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningParameterUpdate) ProtoMessage() {}
+
+func (x *TuningParameterUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuningParameterUpdate.ProtoReflect.Descriptor instead.
+func (*TuningParameterUpdate) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TuningParameterUpdate) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TuningParameterUpdate) GetParameter() *core.TuningState_Parameter {
+	if x != nil {
+		return x.Parameter
+	}
+	return nil
+}
+
+func (x *TuningParameterUpdate) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *TuningParameterUpdate) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// Asks core for the tuning revisions that it still remembers
+type TuningRevisionListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TuningRevisionListRequest) Reset() {
+	*x = TuningRevisionListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TuningRevisionListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningRevisionListRequest) ProtoMessage() {}
+
+func (x *TuningRevisionListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuningRevisionListRequest.ProtoReflect.Descriptor instead.
+func (*TuningRevisionListRequest) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{18}
+}
+
+type TuningRevisionList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Revisions []*TuningRevision `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"` // oldest first
+}
+
+func (x *TuningRevisionList) Reset() {
+	*x = TuningRevisionList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TuningRevisionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningRevisionList) ProtoMessage() {}
+
+func (x *TuningRevisionList) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuningRevisionList.ProtoReflect.Descriptor instead.
+func (*TuningRevisionList) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TuningRevisionList) GetRevisions() []*TuningRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+// Asks core for the differences between two tuning revisions
+type TuningRevisionDiffRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From uint64 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   uint64 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *TuningRevisionDiffRequest) Reset() {
+	*x = TuningRevisionDiffRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TuningRevisionDiffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningRevisionDiffRequest) ProtoMessage() {}
+
+func (x *TuningRevisionDiffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuningRevisionDiffRequest.ProtoReflect.Descriptor instead.
+func (*TuningRevisionDiffRequest) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TuningRevisionDiffRequest) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *TuningRevisionDiffRequest) GetTo() uint64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type TuningRevisionDiff struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From    uint64                   `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To      uint64                   `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	Changes []*TuningParameterChange `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *TuningRevisionDiff) Reset() {
+	*x = TuningRevisionDiff{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TuningRevisionDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningRevisionDiff) ProtoMessage() {}
+
+func (x *TuningRevisionDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuningRevisionDiff.ProtoReflect.Descriptor instead.
+func (*TuningRevisionDiff) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TuningRevisionDiff) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *TuningRevisionDiff) GetTo() uint64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *TuningRevisionDiff) GetChanges() []*TuningParameterChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// Asks core to restore the tuning state of an earlier revision. Core replies with (and broadcasts) the resulting tuning state,
+// just like it does for a regular tuning state upsert
+type TuningRollbackRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Revision uint64 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+}
+
+func (x *TuningRollbackRequest) Reset() {
+	*x = TuningRollbackRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TuningRollbackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningRollbackRequest) ProtoMessage() {}
+
+func (x *TuningRollbackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuningRollbackRequest.ProtoReflect.Descriptor instead.
+func (*TuningRollbackRequest) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TuningRollbackRequest) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+// The change of a single tuning parameter, as kept in the audit log. Every change to the tuning state results in one record per changed key
+type TuningAuditRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timestamp uint64                 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // the timestamp of the tuning state after the change
+	Revision  uint64                 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`   // the revision that the change resulted in. Revision numbers start over when core restarts without a tuning state file
+	Client    string                 `protobuf:"bytes,3,opt,name=client,proto3" json:"client,omitempty"`        // the identity of the client that made the change, empty if it did not give one
+	Change    *TuningParameterChange `protobuf:"bytes,4,opt,name=change,proto3" json:"change,omitempty"`
+}
+
+func (x *TuningAuditRecord) Reset() {
+	*x = TuningAuditRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TuningAuditRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningAuditRecord) ProtoMessage() {}
+
+func (x *TuningAuditRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuningAuditRecord.ProtoReflect.Descriptor instead.
+func (*TuningAuditRecord) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TuningAuditRecord) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *TuningAuditRecord) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *TuningAuditRecord) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+func (x *TuningAuditRecord) GetChange() *TuningParameterChange {
+	if x != nil {
+		return x.Change
+	}
+	return nil
+}
+
+// Asks core for the audit records of the changes to the tuning state
+type TuningAuditRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key  string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`    // only the records of this key, or of all keys if empty
+	From uint64 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"` // only the records with a timestamp from this one on, if set
+	To   uint64 `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`     // only the records with a timestamp up to (and including) this one, if set
+}
+
+func (x *TuningAuditRequest) Reset() {
+	*x = TuningAuditRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TuningAuditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningAuditRequest) ProtoMessage() {}
+
+func (x *TuningAuditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuningAuditRequest.ProtoReflect.Descriptor instead.
+func (*TuningAuditRequest) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TuningAuditRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TuningAuditRequest) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *TuningAuditRequest) GetTo() uint64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type TuningAuditTrail struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Records []*TuningAuditRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"` // oldest first
+}
+
+func (x *TuningAuditTrail) Reset() {
+	*x = TuningAuditTrail{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TuningAuditTrail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuningAuditTrail) ProtoMessage() {}
+
+func (x *TuningAuditTrail) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuningAuditTrail.ProtoReflect.Descriptor instead.
+func (*TuningAuditTrail) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TuningAuditTrail) GetRecords() []*TuningAuditRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+// Asks core for the state of everything that it broadcasts about. A client that subscribes to the broadcasts before asking for a snapshot
+// only has to apply the broadcasts with a higher sequence number than the snapshot (the ZeroMQ clone pattern)
+type SnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SnapshotRequest) Reset() {
+	*x = SnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotRequest) ProtoMessage() {}
+
+func (x *SnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{26}
+}
+
+type Snapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// every broadcast up to this sequence number is reflected in the snapshot. Later broadcasts might be as well, but since they
+	// carry the entire service or tuning state, applying them again is harmless
+	Sequence uint64          `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Services []*core.Service `protobuf:"bytes,2,rep,name=services,proto3" json:"services,omitempty"`
+	Tuning   *TuningRevision `protobuf:"bytes,3,opt,name=tuning,proto3" json:"tuning,omitempty"` // the current tuning revision, with the tuning state as services see it
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Snapshot) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Snapshot) GetServices() []*core.Service {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *Snapshot) GetTuning() *TuningRevision {
+	if x != nil {
+		return x.Tuning
+	}
+	return nil
+}
+
+// Broadcast when the status of a registered service changes, or when it is removed from the registry
+type ServiceEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Service        *core.Service         `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"` // with its new status, which is STOPPED if it was removed
+	PreviousStatus core.ServiceStatus    `protobuf:"varint,2,opt,name=previousStatus,proto3,enum=protobuf_msgs.ServiceStatus" json:"previousStatus,omitempty"`
+	Removed        bool                  `protobuf:"varint,3,opt,name=removed,proto3" json:"removed,omitempty"`
+	Reason         ServiceEvent_Reason   `protobuf:"varint,4,opt,name=reason,proto3,enum=core_extensions.ServiceEvent_Reason" json:"reason,omitempty"`
+	ExtendedStatus ExtendedServiceStatus `protobuf:"varint,5,opt,name=extendedStatus,proto3,enum=core_extensions.ExtendedServiceStatus" json:"extendedStatus,omitempty"` // of the service after the change
+}
+
+func (x *ServiceEvent) Reset() {
+	*x = ServiceEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extensions_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServiceEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceEvent) ProtoMessage() {}
+
+func (x *ServiceEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_extensions_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceEvent.ProtoReflect.Descriptor instead.
+func (*ServiceEvent) Descriptor() ([]byte, []int) {
+	return file_extensions_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ServiceEvent) GetService() *core.Service {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+func (x *ServiceEvent) GetPreviousStatus() core.ServiceStatus {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return core.ServiceStatus(0)
+}
+
+func (x *ServiceEvent) GetRemoved() bool {
+	if x != nil {
+		return x.Removed
+	}
+	return false
+}
+
+func (x *ServiceEvent) GetReason() ServiceEvent_Reason {
+	if x != nil {
+		return x.Reason
+	}
+	return ServiceEvent_REPORTED
+}
+
+func (x *ServiceEvent) GetExtendedStatus() ExtendedServiceStatus {
+	if x != nil {
+		return x.ExtendedStatus
+	}
+	return ExtendedServiceStatus_NO_EXTENDED_STATUS
+}
+
+var File_extensions_proto protoreflect.FileDescriptor
+
+var file_extensions_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x1a, 0x16, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x74, 0x75, 0x6e, 0x69, 0x6e, 0x67,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x63, 0x6f, 0x72,
+	0x65, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65,
+	0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe0, 0x0f, 0x0a, 0x14, 0x43, 0x6f, 0x72,
+	0x65, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x32, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x5f, 0x6d, 0x73,
+	0x67, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x48, 0x00, 0x52, 0x07, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3e, 0x0a, 0x0b, 0x74, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x5f, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x54, 0x75, 0x6e, 0x69, 0x6e,
+	0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x74, 0x75, 0x6e, 0x69, 0x6e, 0x67,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x36, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x6a, 0x0a,
+	0x19, 0x74, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x64, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x19,
+	0x74, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x55, 0x0a, 0x12, 0x74, 0x75, 0x6e,
+	0x69, 0x6e, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x18,
+	0x65, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74,
+	0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x12, 0x74, 0x75,
+	0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x6a, 0x0a, 0x19, 0x74, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x66, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x19, 0x74, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x55, 0x0a, 0x12,
+	0x74, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x69,
+	0x66, 0x66, 0x18, 0x67, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f,
+	0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54, 0x75, 0x6e, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x69, 0x66, 0x66, 0x48, 0x00, 0x52,
+	0x12, 0x74, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x44,
+	0x69, 0x66, 0x66, 0x12, 0x5e, 0x0a, 0x15, 0x74, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x6f, 0x6c,
+	0x6c, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x68, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x6f, 0x6c, 0x6c, 0x62,
+	0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x15, 0x74, 0x75,
+	0x6e, 0x69, 0x6e, 0x67, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x58, 0x0a, 0x13, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x69, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x13, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x52, 0x0a,
+	0x11, 0x74, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x55, 0x70, 0x73, 0x65,
+	0x72, 0x74, 0x18, 0x6a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f,
+	0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54, 0x75, 0x6e, 0x69, 0x6e,
+	0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x55, 0x70, 0x73, 0x65, 0x72, 0x74, 0x48, 0x00, 0x52, 0x11,
+	0x74, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x55, 0x70, 0x73, 0x65, 0x72,
+	0x74, 0x12, 0x49, 0x0a, 0x0e, 0x74, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x6b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54, 0x75, 0x6e, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0e, 0x74, 0x75,
+	0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x6a, 0x0a, 0x19,
+	0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x6c, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2a, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x19, 0x61,
+	0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x5e, 0x0a, 0x15, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x44, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x6d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x44, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48,
+	0x00, 0x52, 0x15, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x72,
+	0x74, 0x62, 0x65, 0x61, 0x74, 0x18, 0x6e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x48, 0x65,
+	0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x48, 0x00, 0x52, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74,
+	0x62, 0x65, 0x61, 0x74, 0x12, 0x2e, 0x0a, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x6f, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x48, 0x00, 0x52, 0x05, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x12, 0x64, 0x0a, 0x17, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0x70, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74,
+	0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x17, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x58, 0x0a, 0x13, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4c, 0x69, 0x73,
+	0x74, 0x18, 0x71, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x13, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x4c, 0x69, 0x73, 0x74, 0x12, 0x4c, 0x0a, 0x0f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x72, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x0f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x37, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x73,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x48,
+	0x00, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x43, 0x0a, 0x0c, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x74, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x48, 0x00, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x55, 0x0a, 0x12, 0x74, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x41, 0x75, 0x64, 0x69, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x75, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54,
+	0x75, 0x6e, 0x69, 0x6e, 0x67, 0x41, 0x75, 0x64, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x12, 0x74, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x41, 0x75, 0x64, 0x69, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4f, 0x0a, 0x10, 0x74, 0x75, 0x6e, 0x69, 0x6e,
+	0x67, 0x41, 0x75, 0x64, 0x69, 0x74, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x18, 0x76, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x2e, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x41, 0x75, 0x64, 0x69, 0x74, 0x54,
+	0x72, 0x61, 0x69, 0x6c, 0x48, 0x00, 0x52, 0x10, 0x74, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x41, 0x75,
+	0x64, 0x69, 0x74, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x12, 0x5e, 0x0a, 0x15, 0x74, 0x75, 0x6e, 0x69,
+	0x6e, 0x67, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x18, 0x77, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x48,
+	0x00, 0x52, 0x15, 0x74, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x6a, 0x0a, 0x19, 0x61, 0x75, 0x74, 0x68,
+	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x78, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x41, 0x75,
+	0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x48, 0x00, 0x52, 0x19, 0x61, 0x75, 0x74, 0x68, 0x65,
+	0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x42, 0x05, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x22, 0xbe, 0x01, 0x0a, 0x0d,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x4c, 0x0a, 0x10, 0x74, 0x75, 0x6e, 0x69, 0x6e,
+	0x67, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x2e, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x10, 0x74, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x56, 0x69, 0x6f, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x45, 0x0a, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54,
+	0x75, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0xd7, 0x02, 0x0a,
+	0x13, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x5f, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x07, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4a, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72,
+	0x61, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x73, 0x74,
+	0x72, 0x61, 0x69, 0x6e, 0x74, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e,
+	0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0d, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a,
+	0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x65, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x0f,
+	0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x22, 0x71, 0x0a, 0x11, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x62, 0x69, 0x6e, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x69, 0x6e, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x49, 0x0a, 0x17, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x22, 0xf1, 0x01, 0x0a, 0x0f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x5f, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x45, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x09, 0x65,
+	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x4e, 0x0a, 0x0e, 0x65, 0x78, 0x74, 0x65,
+	0x6e, 0x64, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x26, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64,
+	0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x55, 0x0a, 0x13, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x3e, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x22,
+	0x5d, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x3a, 0x0a, 0x07,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x5f, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52,
+	0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x7d,
+	0x0a, 0x05, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x5f, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1c, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x6d, 0x0a,
+	0x19, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x3a, 0x0a, 0x06, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x5f, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x06,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x64, 0x0a, 0x19,
+	0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x31, 0x0a, 0x05, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x5f, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x22, 0x69, 0x0a, 0x15, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x72,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x07, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x5f, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x07,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xab, 0x01,
+	0x0a, 0x17, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x43,
+	0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x15, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00,
+	0x52, 0x03, 0x6d, 0x69, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x15, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x88, 0x01, 0x01, 0x12,
+	0x17, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x48, 0x02, 0x52,
+	0x04, 0x73, 0x74, 0x65, 0x70, 0x88, 0x01, 0x01, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x65, 0x64, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x77,
+	0x65, 0x64, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6d, 0x69, 0x6e, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6d,
+	0x61, 0x78, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x73, 0x74, 0x65, 0x70, 0x22, 0xfc, 0x01, 0x0a, 0x11,
+	0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x55, 0x70, 0x73, 0x65, 0x72,
+	0x74, 0x12, 0x3b, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x27, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x2e, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x55, 0x70, 0x73,
+	0x65, 0x72, 0x74, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x30,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x5f, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x54, 0x75,
+	0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x73,
+	0x12, 0x27, 0x0a, 0x0c, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x0c, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x22, 0x1e, 0x0a, 0x04, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x50, 0x4c, 0x41, 0x43, 0x45, 0x10, 0x00, 0x12, 0x09,
+	0x0a, 0x05, 0x4d, 0x45, 0x52, 0x47, 0x45, 0x10, 0x01, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x62, 0x61,
+	0x73, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x3b, 0x0a, 0x0f, 0x54, 0x75,
+	0x6e, 0x69, 0x6e, 0x67, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x9e, 0x01, 0x0a, 0x0e, 0x54, 0x75, 0x6e, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x4b,
+	0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x5f, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0x99, 0x01, 0x0a, 0x15, 0x54, 0x75, 0x6e,
+	0x69, 0x6e, 0x67, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x36, 0x0a, 0x03, 0x6f, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x5f, 0x6d, 0x73, 0x67,
+	0x73, 0x2e, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x52, 0x03, 0x6f, 0x6c, 0x64, 0x12, 0x36, 0x0a, 0x03,
+	0x6e, 0x65, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x5f, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x52,
+	0x03, 0x6e, 0x65, 0x77, 0x22, 0xa7, 0x01, 0x0a, 0x15, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x50,
+	0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x42, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x5f, 0x6d,
+	0x73, 0x67, 0x73, 0x2e, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x52, 0x09, 0x70, 0x61, 0x72, 0x61, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x1b,
+	0x0a, 0x19, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x53, 0x0a, 0x12, 0x54,
+	0x75, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73,
+	0x74, 0x12, 0x3d, 0x0a, 0x09, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x22, 0x3f, 0x0a, 0x19, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74,
+	0x6f, 0x22, 0x7a, 0x0a, 0x12, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x44, 0x69, 0x66, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74,
+	0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x40, 0x0a, 0x07, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54,
+	0x75, 0x6e, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x33, 0x0a,
+	0x15, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x22, 0xa5, 0x01, 0x0a, 0x11, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67, 0x41, 0x75, 0x64,
+	0x69, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x3e, 0x0a, 0x06, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54, 0x75, 0x6e,
+	0x69, 0x6e, 0x67, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x52, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x4a, 0x0a, 0x12, 0x54, 0x75,
+	0x6e, 0x69, 0x6e, 0x67, 0x41, 0x75, 0x64, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x50, 0x0a, 0x10, 0x54, 0x75, 0x6e, 0x69, 0x6e, 0x67,
+	0x41, 0x75, 0x64, 0x69, 0x74, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x12, 0x3c, 0x0a, 0x07, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54, 0x75,
+	0x6e, 0x69, 0x6e, 0x67, 0x41, 0x75, 0x64, 0x69, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52,
+	0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x11, 0x0a, 0x0f, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x93, 0x01, 0x0a, 0x08,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x5f, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x08,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x37, 0x0a, 0x06, 0x74, 0x75, 0x6e, 0x69,
+	0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f,
+	0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x54, 0x75, 0x6e, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x74, 0x75, 0x6e, 0x69, 0x6e,
+	0x67, 0x22, 0xb9, 0x03, 0x0a, 0x0c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x30, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x5f, 0x6d,
+	0x73, 0x67, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x07, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x44, 0x0a, 0x0e, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x5f, 0x6d, 0x73, 0x67, 0x73, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0e, 0x70, 0x72, 0x65, 0x76,
+	0x69, 0x6f, 0x75, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x64, 0x12, 0x3c, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x12, 0x4e, 0x0a, 0x0e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x45, 0x78, 0x74,
+	0x65, 0x6e, 0x64, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x0e, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x22, 0x88, 0x01, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x0c, 0x0a,
+	0x08, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x50,
+	0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x47, 0x4f, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x11, 0x0a,
+	0x0d, 0x45, 0x58, 0x50, 0x4c, 0x49, 0x43, 0x49, 0x54, 0x5f, 0x53, 0x54, 0x4f, 0x50, 0x10, 0x02,
+	0x12, 0x11, 0x0a, 0x0d, 0x4c, 0x45, 0x41, 0x53, 0x45, 0x5f, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45,
+	0x44, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x4c, 0x45, 0x41, 0x53, 0x45, 0x5f, 0x52, 0x45, 0x4e,
+	0x45, 0x57, 0x45, 0x44, 0x10, 0x04, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x52, 0x41, 0x53, 0x48, 0x5f,
+	0x4c, 0x4f, 0x4f, 0x50, 0x49, 0x4e, 0x47, 0x10, 0x05, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45, 0x50,
+	0x4c, 0x41, 0x43, 0x45, 0x44, 0x10, 0x06, 0x22, 0x04, 0x08, 0x07, 0x10, 0x07, 0x2a, 0x54, 0x0a,
+	0x15, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x12, 0x4e, 0x4f, 0x5f, 0x45, 0x58, 0x54,
+	0x45, 0x4e, 0x44, 0x45, 0x44, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x10, 0x00, 0x12, 0x11,
+	0x0a, 0x0d, 0x43, 0x52, 0x41, 0x53, 0x48, 0x5f, 0x4c, 0x4f, 0x4f, 0x50, 0x49, 0x4e, 0x47, 0x10,
+	0x01, 0x12, 0x10, 0x0a, 0x0c, 0x55, 0x4e, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x49, 0x56,
+	0x45, 0x10, 0x02, 0x42, 0x2f, 0x5a, 0x2d, 0x76, 0x75, 0x2f, 0x61, 0x73, 0x65, 0x2f, 0x63, 0x6f,
+	0x72, 0x65, 0x2f, 0x73, 0x72, 0x63, 0x2f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x3b, 0x70, 0x62, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_extensions_proto_rawDescOnce sync.Once
+	file_extensions_proto_rawDescData = file_extensions_proto_rawDesc
+)
+
+func file_extensions_proto_rawDescGZIP() []byte {
+	file_extensions_proto_rawDescOnce.Do(func() {
+		file_extensions_proto_rawDescData = protoimpl.X.CompressGZIP(file_extensions_proto_rawDescData)
+	})
+	return file_extensions_proto_rawDescData
+}
+
+var file_extensions_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_extensions_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_extensions_proto_goTypes = []any{
+	(ExtendedServiceStatus)(0),         // 0: core_extensions.ExtendedServiceStatus
+	(TuningStateUpsert_Mode)(0),        // 1: core_extensions.TuningStateUpsert.Mode
+	(ServiceEvent_Reason)(0),           // 2: core_extensions.ServiceEvent.Reason
+	(*CoreExtensionMessage)(nil),       // 3: core_extensions.CoreExtensionMessage
+	(*DetailedError)(nil),              // 4: core_extensions.DetailedError
+	(*ServiceRegistration)(nil),        // 5: core_extensions.ServiceRegistration
+	(*EndpointAddresses)(nil),          // 6: core_extensions.EndpointAddresses
+	(*ServiceInstancesRequest)(nil),    // 7: core_extensions.ServiceInstancesRequest
+	(*ServiceInstance)(nil),            // 8: core_extensions.ServiceInstance
+	(*ServiceInstanceList)(nil),        // 9: core_extensions.ServiceInstanceList
+	(*Heartbeat)(nil),                  // 10: core_extensions.Heartbeat
+	(*Lease)(nil),                      // 11: core_extensions.Lease
+	(*AuthenticatedStatusUpdate)(nil),  // 12: core_extensions.AuthenticatedStatusUpdate
+	(*AuthenticatedServiceOrder)(nil),  // 13: core_extensions.AuthenticatedServiceOrder
+	(*ServiceDeregistration)(nil),      // 14: core_extensions.ServiceDeregistration
+	(*ServiceOptionConstraint)(nil),    // 15: core_extensions.ServiceOptionConstraint
+	(*TuningStateUpsert)(nil),          // 16: core_extensions.TuningStateUpsert
+	(*TuningViolation)(nil),            // 17: core_extensions.TuningViolation
+	(*TuningRevision)(nil),             // 18: core_extensions.TuningRevision
+	(*TuningParameterChange)(nil),      // 19: core_extensions.TuningParameterChange
+	(*TuningParameterUpdate)(nil),      // 20: core_extensions.TuningParameterUpdate
+	(*TuningRevisionListRequest)(nil),  // 21: core_extensions.TuningRevisionListRequest
+	(*TuningRevisionList)(nil),         // 22: core_extensions.TuningRevisionList
+	(*TuningRevisionDiffRequest)(nil),  // 23: core_extensions.TuningRevisionDiffRequest
+	(*TuningRevisionDiff)(nil),         // 24: core_extensions.TuningRevisionDiff
+	(*TuningRollbackRequest)(nil),      // 25: core_extensions.TuningRollbackRequest
+	(*TuningAuditRecord)(nil),          // 26: core_extensions.TuningAuditRecord
+	(*TuningAuditRequest)(nil),         // 27: core_extensions.TuningAuditRequest
+	(*TuningAuditTrail)(nil),           // 28: core_extensions.TuningAuditTrail
+	(*SnapshotRequest)(nil),            // 29: core_extensions.SnapshotRequest
+	(*Snapshot)(nil),                   // 30: core_extensions.Snapshot
+	(*ServiceEvent)(nil),               // 31: core_extensions.ServiceEvent
+	(*core.Service)(nil),               // 32: protobuf_msgs.Service
+	(*core.TuningState)(nil),           // 33: protobuf_msgs.TuningState
+	(*core.ServiceIdentifier)(nil),     // 34: protobuf_msgs.ServiceIdentifier
+	(*core.ServiceStatusUpdate)(nil),   // 35: protobuf_msgs.ServiceStatusUpdate
+	(*core.ServiceOrder)(nil),          // 36: protobuf_msgs.ServiceOrder
+	(*core.TuningState_Parameter)(nil), // 37: protobuf_msgs.TuningState.Parameter
+	(core.ServiceStatus)(0),            // 38: protobuf_msgs.ServiceStatus
+}
+var file_extensions_proto_depIdxs = []int32{
+	32, // 0: core_extensions.CoreExtensionMessage.service:type_name -> protobuf_msgs.Service
+	33, // 1: core_extensions.CoreExtensionMessage.tuningState:type_name -> protobuf_msgs.TuningState
+	4,  // 2: core_extensions.CoreExtensionMessage.error:type_name -> core_extensions.DetailedError
+	21, // 3: core_extensions.CoreExtensionMessage.tuningRevisionListRequest:type_name -> core_extensions.TuningRevisionListRequest
+	22, // 4: core_extensions.CoreExtensionMessage.tuningRevisionList:type_name -> core_extensions.TuningRevisionList
+	23, // 5: core_extensions.CoreExtensionMessage.tuningRevisionDiffRequest:type_name -> core_extensions.TuningRevisionDiffRequest
+	24, // 6: core_extensions.CoreExtensionMessage.tuningRevisionDiff:type_name -> core_extensions.TuningRevisionDiff
+	25, // 7: core_extensions.CoreExtensionMessage.tuningRollbackRequest:type_name -> core_extensions.TuningRollbackRequest
+	5,  // 8: core_extensions.CoreExtensionMessage.serviceRegistration:type_name -> core_extensions.ServiceRegistration
+	16, // 9: core_extensions.CoreExtensionMessage.tuningStateUpsert:type_name -> core_extensions.TuningStateUpsert
+	18, // 10: core_extensions.CoreExtensionMessage.tuningRevision:type_name -> core_extensions.TuningRevision
+	12, // 11: core_extensions.CoreExtensionMessage.authenticatedStatusUpdate:type_name -> core_extensions.AuthenticatedStatusUpdate
+	14, // 12: core_extensions.CoreExtensionMessage.serviceDeregistration:type_name -> core_extensions.ServiceDeregistration
+	10, // 13: core_extensions.CoreExtensionMessage.heartbeat:type_name -> core_extensions.Heartbeat
+	11, // 14: core_extensions.CoreExtensionMessage.lease:type_name -> core_extensions.Lease
+	7,  // 15: core_extensions.CoreExtensionMessage.serviceInstancesRequest:type_name -> core_extensions.ServiceInstancesRequest
+	9,  // 16: core_extensions.CoreExtensionMessage.serviceInstanceList:type_name -> core_extensions.ServiceInstanceList
+	29, // 17: core_extensions.CoreExtensionMessage.snapshotRequest:type_name -> core_extensions.SnapshotRequest
+	30, // 18: core_extensions.CoreExtensionMessage.snapshot:type_name -> core_extensions.Snapshot
+	31, // 19: core_extensions.CoreExtensionMessage.serviceEvent:type_name -> core_extensions.ServiceEvent
+	27, // 20: core_extensions.CoreExtensionMessage.tuningAuditRequest:type_name -> core_extensions.TuningAuditRequest
+	28, // 21: core_extensions.CoreExtensionMessage.tuningAuditTrail:type_name -> core_extensions.TuningAuditTrail
+	20, // 22: core_extensions.CoreExtensionMessage.tuningParameterUpdate:type_name -> core_extensions.TuningParameterUpdate
+	13, // 23: core_extensions.CoreExtensionMessage.authenticatedServiceOrder:type_name -> core_extensions.AuthenticatedServiceOrder
+	17, // 24: core_extensions.DetailedError.tuningViolations:type_name -> core_extensions.TuningViolation
+	18, // 25: core_extensions.DetailedError.currentTuning:type_name -> core_extensions.TuningRevision
+	32, // 26: core_extensions.ServiceRegistration.service:type_name -> protobuf_msgs.Service
+	15, // 27: core_extensions.ServiceRegistration.constraints:type_name -> core_extensions.ServiceOptionConstraint
+	6,  // 28: core_extensions.ServiceRegistration.endpoints:type_name -> core_extensions.EndpointAddresses
+	32, // 29: core_extensions.ServiceInstance.service:type_name -> protobuf_msgs.Service
+	6,  // 30: core_extensions.ServiceInstance.endpoints:type_name -> core_extensions.EndpointAddresses
+	0,  // 31: core_extensions.ServiceInstance.extendedStatus:type_name -> core_extensions.ExtendedServiceStatus
+	8,  // 32: core_extensions.ServiceInstanceList.instances:type_name -> core_extensions.ServiceInstance
+	34, // 33: core_extensions.Heartbeat.service:type_name -> protobuf_msgs.ServiceIdentifier
+	34, // 34: core_extensions.Lease.service:type_name -> protobuf_msgs.ServiceIdentifier
+	35, // 35: core_extensions.AuthenticatedStatusUpdate.update:type_name -> protobuf_msgs.ServiceStatusUpdate
+	36, // 36: core_extensions.AuthenticatedServiceOrder.order:type_name -> protobuf_msgs.ServiceOrder
+	34, // 37: core_extensions.ServiceDeregistration.service:type_name -> protobuf_msgs.ServiceIdentifier
+	1,  // 38: core_extensions.TuningStateUpsert.mode:type_name -> core_extensions.TuningStateUpsert.Mode
+	33, // 39: core_extensions.TuningStateUpsert.state:type_name -> protobuf_msgs.TuningState
+	33, // 40: core_extensions.TuningRevision.state:type_name -> protobuf_msgs.TuningState
+	37, // 41: core_extensions.TuningParameterChange.old:type_name -> protobuf_msgs.TuningState.Parameter
+	37, // 42: core_extensions.TuningParameterChange.new:type_name -> protobuf_msgs.TuningState.Parameter
+	37, // 43: core_extensions.TuningParameterUpdate.parameter:type_name -> protobuf_msgs.TuningState.Parameter
+	18, // 44: core_extensions.TuningRevisionList.revisions:type_name -> core_extensions.TuningRevision
+	19, // 45: core_extensions.TuningRevisionDiff.changes:type_name -> core_extensions.TuningParameterChange
+	19, // 46: core_extensions.TuningAuditRecord.change:type_name -> core_extensions.TuningParameterChange
+	26, // 47: core_extensions.TuningAuditTrail.records:type_name -> core_extensions.TuningAuditRecord
+	32, // 48: core_extensions.Snapshot.services:type_name -> protobuf_msgs.Service
+	18, // 49: core_extensions.Snapshot.tuning:type_name -> core_extensions.TuningRevision
+	32, // 50: core_extensions.ServiceEvent.service:type_name -> protobuf_msgs.Service
+	38, // 51: core_extensions.ServiceEvent.previousStatus:type_name -> protobuf_msgs.ServiceStatus
+	2,  // 52: core_extensions.ServiceEvent.reason:type_name -> core_extensions.ServiceEvent.Reason
+	0,  // 53: core_extensions.ServiceEvent.extendedStatus:type_name -> core_extensions.ExtendedServiceStatus
+	54, // [54:54] is the sub-list for method output_type
+	54, // [54:54] is the sub-list for method input_type
+	54, // [54:54] is the sub-list for extension type_name
+	54, // [54:54] is the sub-list for extension extendee
+	0,  // [0:54] is the sub-list for field type_name
+}
+
+func init() { file_extensions_proto_init() }
+func file_extensions_proto_init() {
+	if File_extensions_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_extensions_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*CoreExtensionMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*DetailedError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ServiceRegistration); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*EndpointAddresses); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*ServiceInstancesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*ServiceInstance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*ServiceInstanceList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*Heartbeat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*Lease); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*AuthenticatedStatusUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*AuthenticatedServiceOrder); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*ServiceDeregistration); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*ServiceOptionConstraint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*TuningStateUpsert); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*TuningViolation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*TuningRevision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*TuningParameterChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*TuningParameterUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*TuningRevisionListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*TuningRevisionList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*TuningRevisionDiffRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*TuningRevisionDiff); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*TuningRollbackRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*TuningAuditRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*TuningAuditRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*TuningAuditTrail); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*SnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[27].Exporter = func(v any, i int) any {
+			switch v := v.(*Snapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_extensions_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*ServiceEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_extensions_proto_msgTypes[0].OneofWrappers = []any{
+		(*CoreExtensionMessage_Service)(nil),
+		(*CoreExtensionMessage_TuningState)(nil),
+		(*CoreExtensionMessage_Error)(nil),
+		(*CoreExtensionMessage_TuningRevisionListRequest)(nil),
+		(*CoreExtensionMessage_TuningRevisionList)(nil),
+		(*CoreExtensionMessage_TuningRevisionDiffRequest)(nil),
+		(*CoreExtensionMessage_TuningRevisionDiff)(nil),
+		(*CoreExtensionMessage_TuningRollbackRequest)(nil),
+		(*CoreExtensionMessage_ServiceRegistration)(nil),
+		(*CoreExtensionMessage_TuningStateUpsert)(nil),
+		(*CoreExtensionMessage_TuningRevision)(nil),
+		(*CoreExtensionMessage_AuthenticatedStatusUpdate)(nil),
+		(*CoreExtensionMessage_ServiceDeregistration)(nil),
+		(*CoreExtensionMessage_Heartbeat)(nil),
+		(*CoreExtensionMessage_Lease)(nil),
+		(*CoreExtensionMessage_ServiceInstancesRequest)(nil),
+		(*CoreExtensionMessage_ServiceInstanceList)(nil),
+		(*CoreExtensionMessage_SnapshotRequest)(nil),
+		(*CoreExtensionMessage_Snapshot)(nil),
+		(*CoreExtensionMessage_ServiceEvent)(nil),
+		(*CoreExtensionMessage_TuningAuditRequest)(nil),
+		(*CoreExtensionMessage_TuningAuditTrail)(nil),
+		(*CoreExtensionMessage_TuningParameterUpdate)(nil),
+		(*CoreExtensionMessage_AuthenticatedServiceOrder)(nil),
+	}
+	file_extensions_proto_msgTypes[12].OneofWrappers = []any{}
+	file_extensions_proto_msgTypes[13].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_extensions_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   29,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_extensions_proto_goTypes,
+		DependencyIndexes: file_extensions_proto_depIdxs,
+		EnumInfos:         file_extensions_proto_enumTypes,
+		MessageInfos:      file_extensions_proto_msgTypes,
+	}.Build()
+	File_extensions_proto = out.File
+	file_extensions_proto_rawDesc = nil
+	file_extensions_proto_goTypes = nil
+	file_extensions_proto_depIdxs = nil
+}
@@ -0,0 +1,113 @@
+package pb_core_extensions
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Broadcasts can be recorded to a file, to be replayed later (with corectl replay). A recording starts with RecordingHeader,
+// followed by one entry per broadcast: the time at which it was sent (unix nanoseconds, a big-endian int64), the number of frames
+// (a big-endian uint32) and then every frame, prefixed by its length (a big-endian uint32). The frames are those of the broadcast
+// as it was sent, so they can be read with ParseBroadcast
+const RecordingHeader = "CORE-BROADCASTS-1\n"
+
+// Frames larger than this are taken to be a sign of a corrupt recording, broadcasts are nowhere near this size
+const maxRecordedFrameSize = 64 << 20
+
+// Broadcasts have three frames (see ParseBroadcast), a frame count above this is taken to be a sign of a corrupt recording
+const maxRecordedFrames = 16
+
+// A broadcast as it was recorded
+type RecordedBroadcast struct {
+	Time   time.Time
+	Frames [][]byte
+}
+
+// Writes broadcasts to a recording
+type RecordingWriter struct {
+	w io.Writer
+}
+
+// Starts a recording by writing the header to w
+func NewRecordingWriter(w io.Writer) (*RecordingWriter, error) {
+	_, err := io.WriteString(w, RecordingHeader)
+	if err != nil {
+		return nil, err
+	}
+	return &RecordingWriter{w: w}, nil
+}
+
+// Appends a broadcast to the recording. The entry is written at once, so that a crash leaves at most the last entry incomplete
+func (rw *RecordingWriter) Write(broadcast RecordedBroadcast) error {
+	size := 12
+	for _, f := range broadcast.Frames {
+		size += 4 + len(f)
+	}
+	entry := make([]byte, 0, size)
+	entry = binary.BigEndian.AppendUint64(entry, uint64(broadcast.Time.UnixNano()))
+	entry = binary.BigEndian.AppendUint32(entry, uint32(len(broadcast.Frames)))
+	for _, f := range broadcast.Frames {
+		entry = binary.BigEndian.AppendUint32(entry, uint32(len(f)))
+		entry = append(entry, f...)
+	}
+
+	_, err := rw.w.Write(entry)
+	return err
+}
+
+// Reads the broadcasts from a recording, in the order in which they were sent
+type RecordingReader struct {
+	r *bufio.Reader
+}
+
+// Checks that r holds a recording, and returns a reader for its broadcasts
+func NewRecordingReader(r io.Reader) (*RecordingReader, error) {
+	reader := bufio.NewReader(r)
+	header := make([]byte, len(RecordingHeader))
+	_, err := io.ReadFull(reader, header)
+	if err != nil || string(header) != RecordingHeader {
+		return nil, fmt.Errorf("Not a recording of broadcasts (or one of an unsupported version)")
+	}
+	return &RecordingReader{r: reader}, nil
+}
+
+// Returns the next broadcast, or io.EOF when the recording ends. A recording that ends halfway through a broadcast
+// (because core stopped while writing it) returns io.ErrUnexpectedEOF
+func (rr *RecordingReader) Read() (*RecordedBroadcast, error) {
+	head := make([]byte, 12)
+	n, err := io.ReadFull(rr.r, head)
+	if err == io.EOF && n == 0 {
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	frames := binary.BigEndian.Uint32(head[8:])
+	if frames > maxRecordedFrames {
+		return nil, fmt.Errorf("Recorded broadcast of %d frames has too many frames, the recording is corrupt", frames)
+	}
+	broadcast := &RecordedBroadcast{
+		Time:   time.Unix(0, int64(binary.BigEndian.Uint64(head[:8]))),
+		Frames: make([][]byte, frames),
+	}
+	for i := range broadcast.Frames {
+		length := make([]byte, 4)
+		_, err := io.ReadFull(rr.r, length)
+		if err != nil {
+			return nil, io.ErrUnexpectedEOF
+		}
+		size := binary.BigEndian.Uint32(length)
+		if size > maxRecordedFrameSize {
+			return nil, fmt.Errorf("Recorded frame of %d bytes is too large, the recording is corrupt", size)
+		}
+		broadcast.Frames[i] = make([]byte, size)
+		_, err = io.ReadFull(rr.r, broadcast.Frames[i])
+		if err != nil {
+			return nil, io.ErrUnexpectedEOF
+		}
+	}
+	return broadcast, nil
+}
@@ -1,10 +1,15 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"os"
-	"vu/ase/core/src/procutils"
+	"time"
 	"vu/ase/core/src/server"
 	"vu/ase/core/src/state"
+	"vu/ase/core/src/supervisor"
+
+	pb_core_extensions "vu/ase/core/src/extensions"
 
 	pb_core_messages "github.com/VU-ASE/rovercom/packages/go/core"
 	roverlib "github.com/VU-ASE/roverlib/src"
@@ -12,7 +17,35 @@ import (
 )
 
 // Use as a global variable so that the onTerminate callback function can call it
-var systemState state.State
+var systemState *state.State
+
+// Optional pipeline file that lists the services core should start itself. Parsed by roverlib.Run
+var pipelinePath = flag.String("pipeline", "", "path to a pipeline file listing the service directories to launch")
+
+// How many requests are handled at the same time, and how long each of them may take. Parsed by roverlib.Run
+var workers = flag.Int("workers", 4, "number of requests that are handled concurrently")
+var requestTimeout = flag.Duration("request-timeout", 30*time.Second, "time after which a request that is still waiting for a worker is answered with an error")
+
+// Optional host to advertise in endpoint addresses that bind to all interfaces (such as tcp://*:1337). Parsed by roverlib.Run
+var advertisedHost = flag.String("advertised-host", "", "host that clients can reach this machine on, used to resolve wildcard endpoint addresses (defaults to the address of one of the interfaces)")
+
+// Optional range of ports that core assigns to the outputs of services that ask for one. Parsed by roverlib.Run
+var portRange = flag.String("port-range", "", "range of ports to assign to service outputs, such as 7000-7999")
+
+// Optional address to serve the HTTP/JSON gateway (and the WebSocket bridge for broadcasts) on, such as localhost:8080. Parsed by roverlib.Run
+var httpAddr = flag.String("http", "", "address to serve the HTTP/JSON gateway and WebSocket bridge on (disabled if empty)")
+
+// Optional address to serve Prometheus metrics on (at /metrics), such as localhost:9100. Parsed by roverlib.Run
+var metricsAddr = flag.String("metrics", "", "address to serve Prometheus metrics on (disabled if empty)")
+
+// Optional file to keep the tuning state in, so that it survives a restart of core. Parsed by roverlib.Run
+var tuningStatePath = flag.String("tuning-file", "", "path to the file in which the tuning state is saved and restored from")
+
+// Optional file to append a record of every change to a tuning parameter to. Parsed by roverlib.Run
+var auditLogPath = flag.String("audit-file", "", "path to the file that a record of every tuning change is appended to, and that the audit trail is restored from")
+
+// Optional file to record every broadcast to, so that the sequence can be replayed later with corectl replay. Parsed by roverlib.Run
+var recordPath = flag.String("record", "", "path to the file to record every broadcast to (replacing its contents), for replaying later")
 
 // The actual program
 func run(service roverlib.ResolvedService, coreInfo roverlib.CoreInfo, initialTuningState *pb_core_messages.TuningState) error {
@@ -28,14 +61,54 @@ func run(service roverlib.ResolvedService, coreInfo roverlib.CoreInfo, initialTu
 	}
 	defer pubsubSocket.Close()
 
+	if *recordPath != "" {
+		err = server.StartRecording(*recordPath)
+		if err != nil {
+			return err
+		}
+		defer server.StopRecording()
+	}
+
+	var ports state.PortRange
+	if *portRange != "" {
+		ports, err = state.ParsePortRange(*portRange)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Create the state, so that other services can use pubsubSocket
-	systemState = state.State{
-		Services:        make(state.ServiceList, 0),
-		PublisherSocket: pubsubSocket,
-		TuningState: &pb_core_messages.TuningState{
-			Timestamp:         0,
-			DynamicParameters: []*pb_core_messages.TuningState_Parameter{},
-		},
+	systemState = state.NewState(pubsubSocket, *tuningStatePath, *advertisedHost, ports)
+	systemState.OnServiceEvent = func(event *pb_core_extensions.ServiceEvent) {
+		err := server.BroadcastServiceEvent(pubsubSocket, event)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to broadcast service event")
+		}
+	}
+
+	// Restore the tuning state from a previous run, before anyone can ask for it
+	if *tuningStatePath != "" {
+		restoredTuning, err := state.LoadTuningState(*tuningStatePath)
+		if err == nil {
+			log.Info().Str("path", *tuningStatePath).Uint64("revision", restoredTuning.Revision).Int("parameters", len(restoredTuning.State.DynamicParameters)).Msg("Restored tuning state")
+			systemState.RestoreTuningState(restoredTuning)
+		} else if errors.Is(err, os.ErrNotExist) {
+			log.Info().Str("path", *tuningStatePath).Msg("No tuning state to restore yet")
+		} else {
+			return err
+		}
+	}
+
+	// Restore the audit trail of previous runs, so that it can be queried across restarts
+	if *auditLogPath != "" {
+		records, err := state.LoadAuditLog(*auditLogPath)
+		if err == nil {
+			log.Info().Str("path", *auditLogPath).Int("records", len(records)).Msg("Restored audit log")
+			systemState.RestoreAuditLog(records)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		systemState.AuditLogPath = *auditLogPath
 	}
 
 	// Get the address to listen on, defined in our service.yaml
@@ -63,22 +136,52 @@ func run(service roverlib.ResolvedService, coreInfo roverlib.CoreInfo, initialTu
 		Status: pb_core_messages.ServiceStatus_RUNNING,
 	})
 
+	// Let everyone know when the supervisor gives up on a service
+	supervisor.OnStatusChange = func(service *pb_core_messages.Service, extendedStatus pb_core_extensions.ExtendedServiceStatus) {
+		err := server.BroadcastServiceEvent(pubsubSocket, &pb_core_extensions.ServiceEvent{
+			Service:        service,
+			Reason:         pb_core_extensions.ServiceEvent_CRASH_LOOPING,
+			ExtendedStatus: extendedStatus,
+		})
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to broadcast service status change")
+		}
+	}
+
+	// Start the services from the pipeline in the background, each one once the services it depends on have registered with the server
+	if *pipelinePath != "" {
+		err = supervisor.LaunchPipeline(*pipelinePath, systemState)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Tools that cannot speak ZeroMQ can use the HTTP gateway instead
+	if *httpAddr != "" {
+		err = server.StartHTTPGateway(*httpAddr, *requestTimeout, systemState)
+		if err != nil {
+			return err
+		}
+	}
+
+	if *metricsAddr != "" {
+		err = server.StartMetricsServer(*metricsAddr, systemState)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Now run the main req/rep server loop, which can use the publisher socket to broadcast messages
-	return server.Serve(reqrepAddr, &systemState)
+	return server.Serve(reqrepAddr, *workers, *requestTimeout, systemState)
 }
 
 func onTerminate(signal os.Signal) {
 	log.Info().Msg("Gracefully terminating system manager")
 
-	// send SIGTERM to all services
-	for _, service := range systemState.Services {
-		log.Info().Msgf("Killing '%v' process", service.Identifier.Name)
-		err := procutils.KillProcess(int(service.Identifier.Pid))
-		if err != nil {
-			log.Err(err).Msgf("Failed to kill '%v' process", service.Identifier.Name)
-		} else {
-			log.Info().Msgf("Killed '%v' process", service.Identifier.Name)
-		}
+	// Only stop the processes that we started, services that registered on their own are not ours to stop
+	// The state does not exist yet if core is terminated before it was set up
+	if systemState != nil {
+		supervisor.StopAll(systemState)
 	}
 }
 
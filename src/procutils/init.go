@@ -1,12 +1,30 @@
 package procutils
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"syscall"
+	"time"
 )
 
+// Everything needed to start a process again the way it was started before
+type ProcessCommand struct {
+	Path string
+	Args []string
+	Dir  string
+	Env  []string
+}
+
+// A process that we started ourselves. Exited receives the result of waiting for the process exactly once, when it exits
+type StartedProcess struct {
+	Pid    int
+	Exited <-chan error
+}
+
 // Checks whether a process with the given pid (still) exists
 func ProcessExists(pid int) bool {
 	process, err := os.FindProcess(pid)
@@ -23,3 +41,100 @@ func KillProcess(pid int) error {
 	kill := exec.Command("kill", strconv.Itoa(pid))
 	return kill.Run()
 }
+
+// Sends SIGKILL to the process, which cannot be caught or ignored
+func ForceKillProcess(pid int) error {
+	kill := exec.Command("kill", "-9", strconv.Itoa(pid))
+	return kill.Run()
+}
+
+// Polls until the process with the given pid no longer exists, or until the timeout expires.
+// Returns true if the process exited within the timeout
+func WaitForExit(pid int, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for ProcessExists(pid) {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return true
+}
+
+// Reads the command line, working directory and environment of a running process from /proc
+func GetProcessCommand(pid int) (*ProcessCommand, error) {
+	procDir := filepath.Join("/proc", strconv.Itoa(pid))
+
+	cmdline, err := os.ReadFile(filepath.Join(procDir, "cmdline"))
+	if err != nil {
+		return nil, err
+	}
+	args := splitNullSeparated(cmdline)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("Process %d has an empty command line", pid)
+	}
+
+	path, err := os.Readlink(filepath.Join(procDir, "exe"))
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.Readlink(filepath.Join(procDir, "cwd"))
+	if err != nil {
+		return nil, err
+	}
+
+	environ, err := os.ReadFile(filepath.Join(procDir, "environ"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProcessCommand{
+		Path: path,
+		Args: args,
+		Dir:  dir,
+		Env:  splitNullSeparated(environ),
+	}, nil
+}
+
+// Starts a new process from the given command. The process is reaped in the background
+// so that it does not linger as a zombie (which would make ProcessExists report it as alive)
+func StartProcess(command *ProcessCommand) (*StartedProcess, error) {
+	if command == nil || len(command.Args) == 0 {
+		return nil, fmt.Errorf("Cannot start a process without a command")
+	}
+
+	// Prefer the resolved executable path, the original argv[0] might have been relative to another directory
+	cmd := exec.Command(command.Args[0], command.Args[1:]...)
+	if command.Path != "" {
+		cmd = &exec.Cmd{
+			Path: command.Path,
+			Args: command.Args,
+		}
+	}
+	cmd.Dir = command.Dir
+	cmd.Env = command.Env
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err := cmd.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+	return &StartedProcess{
+		Pid:    cmd.Process.Pid,
+		Exited: exited,
+	}, nil
+}
+
+func splitNullSeparated(data []byte) []string {
+	parts := strings.Split(strings.TrimRight(string(data), "\x00"), "\x00")
+	if len(parts) == 1 && parts[0] == "" {
+		return []string{}
+	}
+	return parts
+}
@@ -0,0 +1,405 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"vu/ase/core/src/state"
+
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	pb_core_messages "github.com/VU-ASE/rovercom/packages/go/core"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
+)
+
+// Handles the messages that core supports on top of the rovercom CoreMessage types (see src/extensions/extensions.proto)
+func handleExtensionMessage(request *Request, state *state.State) (proto.Message, error) {
+	parsedMessage := pb_core_extensions.CoreExtensionMessage{}
+	err := proto.Unmarshal(request.Body, &parsedMessage)
+	if err != nil {
+		return handleUnsupported()
+	}
+
+	switch {
+	case parsedMessage.GetServiceRegistration() != nil:
+		{
+			res, err := handleServiceRegistrationExtension(parsedMessage.GetServiceRegistration(), state)
+			return &pb_core_extensions.CoreExtensionMessage{
+				Msg: &pb_core_extensions.CoreExtensionMessage_ServiceRegistration{
+					ServiceRegistration: res,
+				},
+			}, err
+		}
+	case parsedMessage.GetAuthenticatedStatusUpdate() != nil:
+		{
+			res, err := handleAuthenticatedStatusUpdate(parsedMessage.GetAuthenticatedStatusUpdate(), state)
+			return &pb_core_extensions.CoreExtensionMessage{
+				Msg: &pb_core_extensions.CoreExtensionMessage_Service{
+					Service: res,
+				},
+			}, err
+		}
+	case parsedMessage.GetServiceDeregistration() != nil:
+		{
+			res, err := handleServiceDeregistration(parsedMessage.GetServiceDeregistration(), state)
+			return &pb_core_extensions.CoreExtensionMessage{
+				Msg: &pb_core_extensions.CoreExtensionMessage_Service{
+					Service: res,
+				},
+			}, err
+		}
+	case parsedMessage.GetAuthenticatedServiceOrder() != nil:
+		{
+			res, err := handleAuthenticatedServiceOrder(parsedMessage.GetAuthenticatedServiceOrder(), state)
+			return &pb_core_extensions.CoreExtensionMessage{
+				Msg: &pb_core_extensions.CoreExtensionMessage_Service{
+					Service: res,
+				},
+			}, err
+		}
+	case parsedMessage.GetHeartbeat() != nil:
+		{
+			res, err := handleHeartbeat(parsedMessage.GetHeartbeat(), state)
+			return &pb_core_extensions.CoreExtensionMessage{
+				Msg: &pb_core_extensions.CoreExtensionMessage_Lease{
+					Lease: res,
+				},
+			}, err
+		}
+	case parsedMessage.GetServiceInstancesRequest() != nil:
+		{
+			res, err := handleServiceInstancesRequest(parsedMessage.GetServiceInstancesRequest(), state)
+			return &pb_core_extensions.CoreExtensionMessage{
+				Msg: &pb_core_extensions.CoreExtensionMessage_ServiceInstanceList{
+					ServiceInstanceList: res,
+				},
+			}, err
+		}
+	case parsedMessage.GetTuningStateUpsert() != nil:
+		{
+			res, err := handleTuningStateUpsertExtension(parsedMessage.GetTuningStateUpsert(), request.Identity(), state)
+			return &pb_core_extensions.CoreExtensionMessage{
+				Msg: &pb_core_extensions.CoreExtensionMessage_TuningRevision{
+					TuningRevision: res,
+				},
+			}, err
+		}
+	case parsedMessage.GetTuningRevisionListRequest() != nil:
+		{
+			res := handleTuningRevisionListRequest(state)
+			return &pb_core_extensions.CoreExtensionMessage{
+				Msg: &pb_core_extensions.CoreExtensionMessage_TuningRevisionList{
+					TuningRevisionList: res,
+				},
+			}, nil
+		}
+	case parsedMessage.GetTuningRevisionDiffRequest() != nil:
+		{
+			res, err := handleTuningRevisionDiffRequest(parsedMessage.GetTuningRevisionDiffRequest(), state)
+			return &pb_core_extensions.CoreExtensionMessage{
+				Msg: &pb_core_extensions.CoreExtensionMessage_TuningRevisionDiff{
+					TuningRevisionDiff: res,
+				},
+			}, err
+		}
+	case parsedMessage.GetTuningRollbackRequest() != nil:
+		{
+			res, err := handleTuningRollbackRequest(parsedMessage.GetTuningRollbackRequest(), request.Identity(), state)
+			return &pb_core_extensions.CoreExtensionMessage{
+				Msg: &pb_core_extensions.CoreExtensionMessage_TuningState{
+					TuningState: res,
+				},
+			}, err
+		}
+	case parsedMessage.GetTuningAuditRequest() != nil:
+		{
+			res := handleTuningAuditRequest(parsedMessage.GetTuningAuditRequest(), state)
+			return &pb_core_extensions.CoreExtensionMessage{
+				Msg: &pb_core_extensions.CoreExtensionMessage_TuningAuditTrail{
+					TuningAuditTrail: res,
+				},
+			}, nil
+		}
+	case parsedMessage.GetSnapshotRequest() != nil:
+		{
+			res := handleSnapshotRequest(state)
+			return &pb_core_extensions.CoreExtensionMessage{
+				Msg: &pb_core_extensions.CoreExtensionMessage_Snapshot{
+					Snapshot: res,
+				},
+			}, nil
+		}
+	default:
+		{
+			return handleUnsupported()
+		}
+	}
+}
+
+// Builds the reply for a request that failed. Errors that carry details are sent as a DetailedError, which clients
+// that only know about CoreMessage can still read as a regular Error
+func errorReply(err error) proto.Message {
+	var conflictErr *state.TuningConflictError
+	if errors.As(err, &conflictErr) {
+		return &pb_core_extensions.CoreExtensionMessage{
+			Msg: &pb_core_extensions.CoreExtensionMessage_Error{
+				Error: &pb_core_extensions.DetailedError{
+					Message:       err.Error(),
+					CurrentTuning: conflictErr.Current,
+				},
+			},
+		}
+	}
+
+	var validationErr *state.TuningValidationError
+	if errors.As(err, &validationErr) {
+		return &pb_core_extensions.CoreExtensionMessage{
+			Msg: &pb_core_extensions.CoreExtensionMessage_Error{
+				Error: &pb_core_extensions.DetailedError{
+					Message:          err.Error(),
+					TuningViolations: validationErr.Violations,
+				},
+			},
+		}
+	}
+
+	return &pb_core_messages.CoreMessage{
+		Msg: &pb_core_messages.CoreMessage_Error{
+			Error: &pb_core_messages.Error{
+				Message: err.Error(),
+			},
+		},
+	}
+}
+
+//
+// REQ-REP extension endpoint handlers
+//
+
+func handleServiceRegistrationExtension(msg *pb_core_extensions.ServiceRegistration, systemState *state.State) (*pb_core_extensions.ServiceRegistration, error) {
+	log.Debug().Msg("[reqrep]: handling extended service registration")
+
+	if msg.Service == nil || msg.Service.Identifier == nil {
+		return nil, fmt.Errorf("Received service registration without a service")
+	}
+
+	// From now on, only the holder of the token of the session can change the status of this service or deregister it
+	registered, err := registerService(msg.Service, state.Registration{
+		Instance:        msg.Instance,
+		AllocateOutputs: msg.AllocateOutputs,
+		Constraints:     msg.Constraints,
+		WithSession:     true,
+		LeaseDuration:   time.Duration(msg.LeaseDuration) * time.Millisecond,
+	}, systemState)
+	if err != nil {
+		return nil, err
+	}
+
+	var leaseDuration uint64
+	if registered.Lease != nil {
+		leaseDuration = uint64(registered.Lease.Duration.Milliseconds())
+	}
+	res := registered.Service
+	return &pb_core_extensions.ServiceRegistration{
+		Service:       res,
+		Constraints:   msg.Constraints,
+		Token:         registered.Session.Token,
+		LeaseDuration: leaseDuration,
+		Instance:      msg.Instance,
+		Endpoints:     endpointAddresses(systemState.GetEndpoints(res.Identifier.Name, res.Identifier.Pid)),
+	}, nil
+}
+
+func handleHeartbeat(msg *pb_core_extensions.Heartbeat, state *state.State) (*pb_core_extensions.Lease, error) {
+	log.Debug().Msg("[reqrep]: handling heartbeat")
+
+	if msg.Service == nil {
+		return nil, fmt.Errorf("Received heartbeat without a service")
+	}
+
+	err := state.Authenticate(msg.Service.Name, msg.Service.Pid, msg.Token)
+	if err != nil {
+		return nil, fmt.Errorf("Could not renew lease of service '%s': %v", msg.Service.Name, err)
+	}
+
+	lease, err := state.RenewLease(msg.Service.Name, msg.Service.Pid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb_core_extensions.Lease{
+		Service:   msg.Service,
+		Duration:  uint64(lease.Duration.Milliseconds()),
+		ExpiresAt: lease.ExpiresAt.UnixMilli(),
+	}, nil
+}
+
+func handleServiceInstancesRequest(msg *pb_core_extensions.ServiceInstancesRequest, state *state.State) (*pb_core_extensions.ServiceInstanceList, error) {
+	log.Debug().Msg("[reqrep]: handling service instances request")
+
+	res := &pb_core_extensions.ServiceInstanceList{
+		Instances: make([]*pb_core_extensions.ServiceInstance, 0),
+	}
+	for _, i := range state.GetServiceInstances(msg.Name) {
+		if msg.Instance != "" && i.ID != msg.Instance {
+			continue
+		}
+		i.Service.Status = state.GetServiceStatus(i.Service)
+		res.Instances = append(res.Instances, &pb_core_extensions.ServiceInstance{
+			Service:        i.Service,
+			Instance:       i.ID,
+			Endpoints:      endpointAddresses(i.Endpoints),
+			ExtendedStatus: state.GetExtendedStatus(i.Service),
+		})
+	}
+
+	if msg.Instance != "" && len(res.Instances) == 0 {
+		log.Warn().Str("service", msg.Name).Str("instance", msg.Instance).Msg("Received service instances request for unregistered instance")
+		return nil, fmt.Errorf("Instance '%s' of service '%s' is not registered", msg.Instance, msg.Name)
+	}
+	return res, nil
+}
+
+func endpointAddresses(endpoints []*state.Endpoint) []*pb_core_extensions.EndpointAddresses {
+	addresses := make([]*pb_core_extensions.EndpointAddresses, 0, len(endpoints))
+	for _, e := range endpoints {
+		addresses = append(addresses, &pb_core_extensions.EndpointAddresses{
+			Name:           e.Name,
+			BindAddress:    e.BindAddress,
+			ConnectAddress: e.ConnectAddress,
+		})
+	}
+	return addresses
+}
+
+func handleAuthenticatedStatusUpdate(msg *pb_core_extensions.AuthenticatedStatusUpdate, state *state.State) (*pb_core_messages.Service, error) {
+	log.Debug().Msg("[reqrep]: handling authenticated service status update")
+
+	update := msg.GetUpdate()
+	if update == nil || update.Service == nil {
+		return nil, fmt.Errorf("Received service status update without a service")
+	}
+
+	err := state.Authenticate(update.Service.Name, update.Service.Pid, msg.Token)
+	if err != nil {
+		return nil, fmt.Errorf("Could not update status of service '%s': %v", update.Service.Name, err)
+	}
+	return state.UpdateServiceStatus(update.Service.Name, update.Service.Pid, update.Status)
+}
+
+func handleAuthenticatedServiceOrder(msg *pb_core_extensions.AuthenticatedServiceOrder, state *state.State) (*pb_core_messages.Service, error) {
+	log.Debug().Msg("[reqrep]: handling authenticated service order")
+
+	if msg.GetOrder() == nil {
+		return nil, fmt.Errorf("Received authenticated service order without an order")
+	}
+	return handleServiceOrder(msg.GetOrder(), msg.Token, state)
+}
+
+func handleServiceDeregistration(msg *pb_core_extensions.ServiceDeregistration, state *state.State) (*pb_core_messages.Service, error) {
+	log.Debug().Msg("[reqrep]: handling service deregistration")
+
+	if msg.Service == nil {
+		return nil, fmt.Errorf("Received service deregistration without a service")
+	}
+
+	service := state.GetServiceByIdentifier(msg.Service.Name, msg.Service.Pid)
+	if service == nil {
+		return nil, fmt.Errorf("Could not deregister service '%s' (pid %d): this service is not registered", msg.Service.Name, msg.Service.Pid)
+	}
+
+	err := state.Authenticate(msg.Service.Name, msg.Service.Pid, msg.Token)
+	if err != nil {
+		return nil, fmt.Errorf("Could not deregister service '%s': %v", msg.Service.Name, err)
+	}
+
+	// The state broadcasts the removal
+	state.RemoveService(service.Identifier.Name, service.Identifier.Pid, pb_core_extensions.ServiceEvent_EXPLICIT_STOP)
+	service.Status = pb_core_messages.ServiceStatus_STOPPED
+	return service, nil
+}
+
+func handleTuningStateUpsertExtension(msg *pb_core_extensions.TuningStateUpsert, client string, systemState *state.State) (*pb_core_extensions.TuningRevision, error) {
+	log.Debug().Str("mode", msg.Mode.String()).Msg("[reqrep]: handling extended tuning state upsert")
+
+	// Both modes come down to a full replacement of the tuning state, they only differ in what they start from
+	update := state.TuningUpdate{
+		State:        msg.State,
+		DeleteKeys:   msg.DeleteKeys,
+		BaseRevision: msg.BaseRevision,
+		Client:       client,
+	}
+	switch msg.Mode {
+	case pb_core_extensions.TuningStateUpsert_REPLACE:
+		if len(msg.DeleteKeys) > 0 {
+			return nil, fmt.Errorf("Keys can only be deleted in a MERGE upsert, a REPLACE upsert removes every key that it does not contain")
+		}
+	case pb_core_extensions.TuningStateUpsert_MERGE:
+		update.Merge = true
+	default:
+		return nil, fmt.Errorf("Unknown tuning state upsert mode %s", msg.Mode.String())
+	}
+
+	return applyTuningUpdate(update, systemState)
+}
+
+func handleTuningRevisionListRequest(state *state.State) *pb_core_extensions.TuningRevisionList {
+	log.Debug().Msg("[reqrep]: handling tuning revision list request")
+
+	return &pb_core_extensions.TuningRevisionList{
+		Revisions: state.GetTuningRevisions(),
+	}
+}
+
+func handleTuningRevisionDiffRequest(msg *pb_core_extensions.TuningRevisionDiffRequest, state *state.State) (*pb_core_extensions.TuningRevisionDiff, error) {
+	log.Debug().Msg("[reqrep]: handling tuning revision diff request")
+
+	changes, err := state.DiffTuningRevisions(msg.From, msg.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb_core_extensions.TuningRevisionDiff{
+		From:    msg.From,
+		To:      msg.To,
+		Changes: changes,
+	}, nil
+}
+
+func handleTuningRollbackRequest(msg *pb_core_extensions.TuningRollbackRequest, client string, state *state.State) (*pb_core_messages.TuningState, error) {
+	log.Debug().Msg("[reqrep]: handling tuning rollback request")
+
+	revision := state.GetTuningRevision(msg.Revision)
+	if revision == nil {
+		log.Warn().Uint64("revision", msg.Revision).Msg("Received rollback request for unknown tuning revision")
+		return nil, fmt.Errorf("Could not roll back to tuning revision %d: this revision does not exist (anymore)", msg.Revision)
+	}
+
+	// A rollback is just an upsert of an old state, so it ends up in the history (and is broadcast) like any other change
+	log.Info().Uint64("revision", msg.Revision).Msg("Rolling back tuning state")
+	return handleTuningStateUpsert(revision.State, client, state)
+}
+
+func handleTuningAuditRequest(msg *pb_core_extensions.TuningAuditRequest, state *state.State) *pb_core_extensions.TuningAuditTrail {
+	log.Debug().Str("key", msg.Key).Msg("[reqrep]: handling tuning audit request")
+
+	return &pb_core_extensions.TuningAuditTrail{
+		Records: state.GetTuningAudit(msg.Key, msg.From, msg.To),
+	}
+}
+
+func handleSnapshotRequest(state *state.State) *pb_core_extensions.Snapshot {
+	log.Debug().Msg("[reqrep]: handling snapshot request")
+
+	state.UpdateServiceStatusses()
+
+	// The state is changed before a change is broadcast, so reading the sequence number first guarantees
+	// that all broadcasts up to it are reflected in the state that is read after
+	sequence := BroadcastSequence()
+	return &pb_core_extensions.Snapshot{
+		Sequence: sequence,
+		Services: state.GetServices(),
+		Tuning:   state.GetCurrentTuningRevision(),
+	}
+}
@@ -0,0 +1,188 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+	"vu/ase/core/src/state"
+
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	pb_core_messages "github.com/VU-ASE/rovercom/packages/go/core"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Tuning states are small, anything larger than this is not a tuning state
+const maxHTTPBodySize = 1 << 20
+
+// A failed HTTP request, with the status code to answer it with
+type httpError struct {
+	status int
+	err    error
+}
+
+func (e *httpError) Error() string {
+	return e.err.Error()
+}
+
+// Starts serving the HTTP/JSON gateway on the given address in the background. It offers the same operations as the req/rep server:
+//
+//	GET /services           the ServiceList
+//	GET /services/{name}    the Service with this name (or with this name and ?pid=), 404 if it is not registered
+//	GET /tuning             the TuningState
+//	PUT /tuning             replaces the tuning state with the TuningState in the body and returns the result, like a tuning state upsert.
+//	                        The X-Client-Identity header (if any) is recorded as the client in the audit log
+//	GET /broadcasts         a WebSocket that relays every broadcast (optionally only those with a ?topic= prefix)
+//
+// All bodies are protojson-encoded. Failed requests are answered with an Error (or a DetailedError if there are details).
+// Requests that take longer than the request timeout are answered with 503, except for PUT /tuning, which always gets its outcome
+func StartHTTPGateway(address string, requestTimeout time.Duration, state *state.State) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("Could not start HTTP gateway on '%s': %v", address, err)
+	}
+
+	server := &http.Server{
+		Handler:           newHTTPGateway(requestTimeout, state),
+		ReadHeaderTimeout: requestTimeout,
+	}
+	log.Info().Str("address", listener.Addr().String()).Msg("Serving HTTP gateway")
+	go func() {
+		err := server.Serve(listener)
+		log.Err(err).Msg("HTTP gateway stopped")
+	}()
+	return nil
+}
+
+// Routes the requests of the HTTP/JSON gateway to their handlers
+func newHTTPGateway(requestTimeout time.Duration, state *state.State) http.Handler {
+	api := http.NewServeMux()
+	api.HandleFunc("GET /services", httpHandler(state, handleHTTPServiceList))
+	api.HandleFunc("GET /services/{name}", httpHandler(state, handleHTTPService))
+	api.HandleFunc("GET /tuning", httpHandler(state, handleHTTPTuningState))
+
+	// A WebSocket stays open for as long as the client wants, so it cannot be subject to the request timeout
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /broadcasts", handleWebsocketBroadcasts)
+	mux.Handle("/", http.TimeoutHandler(api, requestTimeout, "Request was not handled in time"))
+
+	// The timeout would answer a slow upsert with an error while the change is still applied, so only reading its body is bounded
+	upsert := httpHandler(state, handleHTTPTuningStateUpsert)
+	mux.HandleFunc("PUT /tuning", func(w http.ResponseWriter, r *http.Request) {
+		_ = http.NewResponseController(w).SetReadDeadline(time.Now().Add(requestTimeout))
+		upsert(w, r)
+	})
+	return mux
+}
+
+// Wraps a gateway handler, which returns the message to answer with, into an http.HandlerFunc
+func httpHandler(state *state.State, handle func(r *http.Request, state *state.State) (proto.Message, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := handle(r, state)
+		status := http.StatusOK
+		if err != nil {
+			status, res = httpErrorReply(err)
+		}
+
+		body, err := protojson.Marshal(res)
+		if err != nil {
+			log.Err(err).Msg("Could not marshal HTTP reply")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, err = w.Write(body)
+		if err != nil {
+			log.Warn().Err(err).Msg("Could not write HTTP reply")
+		}
+	}
+}
+
+// Picks the status code for a failed request, and the Error (or DetailedError) to send along
+func httpErrorReply(err error) (int, proto.Message) {
+	status := http.StatusInternalServerError
+	var requestErr *httpError
+	var conflictErr *state.TuningConflictError
+	var validationErr *state.TuningValidationError
+	switch {
+	case errors.As(err, &requestErr):
+		status = requestErr.status
+	case errors.As(err, &conflictErr):
+		status = http.StatusConflict
+	case errors.As(err, &validationErr):
+		status = http.StatusUnprocessableEntity
+	}
+
+	// Same message as the req/rep server would send, without the CoreMessage around it
+	switch reply := errorReply(err).(type) {
+	case *pb_core_extensions.CoreExtensionMessage:
+		return status, reply.GetError()
+	case *pb_core_messages.CoreMessage:
+		return status, reply.GetError()
+	default:
+		return status, &pb_core_messages.Error{Message: err.Error()}
+	}
+}
+
+func handleHTTPServiceList(r *http.Request, state *state.State) (proto.Message, error) {
+	log.Debug().Msg("[http]: handling service list request")
+
+	return handleServiceListRequest(state)
+}
+
+func handleHTTPService(r *http.Request, state *state.State) (proto.Message, error) {
+	log.Debug().Msg("[http]: handling service information request")
+
+	requested := &pb_core_messages.ServiceIdentifier{
+		Name: r.PathValue("name"),
+	}
+	if r.URL.Query().Has("pid") {
+		pid, err := strconv.ParseInt(r.URL.Query().Get("pid"), 10, 32)
+		if err != nil {
+			return nil, &httpError{http.StatusBadRequest, fmt.Errorf("Invalid pid '%s': %v", r.URL.Query().Get("pid"), err)}
+		}
+		requested.Pid = int32(pid)
+	}
+
+	service := state.GetService(requested.Name)
+	if requested.Pid != 0 {
+		service = state.GetServiceByIdentifier(requested.Name, requested.Pid)
+	}
+	if service == nil {
+		return nil, &httpError{http.StatusNotFound, fmt.Errorf("Service '%s' (pid %d) is not registered", requested.Name, requested.Pid)}
+	}
+	service.Status = state.GetServiceStatus(service)
+	return service, nil
+}
+
+func handleHTTPTuningState(r *http.Request, state *state.State) (proto.Message, error) {
+	log.Debug().Msg("[http]: handling tuning state request")
+
+	return handleTuningStateRequest(state)
+}
+
+func handleHTTPTuningStateUpsert(r *http.Request, state *state.State) (proto.Message, error) {
+	log.Debug().Msg("[http]: handling tuning state upsert")
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxHTTPBodySize+1))
+	if err != nil {
+		return nil, &httpError{http.StatusBadRequest, fmt.Errorf("Could not read tuning state: %v", err)}
+	}
+	if len(body) > maxHTTPBodySize {
+		return nil, &httpError{http.StatusRequestEntityTooLarge, fmt.Errorf("Tuning state is larger than %d bytes", maxHTTPBodySize)}
+	}
+	tuning := &pb_core_messages.TuningState{}
+	err = protojson.Unmarshal(body, tuning)
+	if err != nil {
+		return nil, &httpError{http.StatusBadRequest, fmt.Errorf("Could not parse tuning state: %v", err)}
+	}
+
+	return handleTuningStateUpsert(tuning, r.Header.Get("X-Client-Identity"), state)
+}
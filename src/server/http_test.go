@@ -0,0 +1,176 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"vu/ase/core/src/state"
+
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	pb_core_messages "github.com/VU-ASE/rovercom/packages/go/core"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Starts the gateway on a state with a single service "imaging", which has a speed option of at most 10
+func newTestGateway(t *testing.T) (*httptest.Server, *state.State) {
+	systemState := state.NewState(nil, "", "", state.PortRange{})
+	maxSpeed := 10.0
+	_, err := systemState.RegisterService(&pb_core_messages.Service{
+		Identifier: &pb_core_messages.ServiceIdentifier{Name: "imaging", Pid: int32(os.Getpid())},
+		Options: []*pb_core_messages.ServiceOption{
+			{Name: "speed", Type: pb_core_messages.ServiceOption_INT, Mutable: true},
+		},
+	}, state.Registration{
+		Constraints: []*pb_core_extensions.ServiceOptionConstraint{{Option: "speed", Max: &maxSpeed}},
+	})
+	if err != nil {
+		t.Fatalf("Could not register service: %v", err)
+	}
+
+	server := httptest.NewServer(newHTTPGateway(time.Second, systemState))
+	t.Cleanup(server.Close)
+	return server, systemState
+}
+
+// Sends a request to the gateway and returns the status code, after reading the body into reply (if it is not nil)
+func doHTTPRequest(t *testing.T, server *httptest.Server, method string, path string, body string, reply proto.Message) int {
+	req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Could not create request: %v", err)
+	}
+	res, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("Could not read reply to %s %s: %v", method, path, err)
+	}
+	if reply != nil {
+		err = protojson.Unmarshal(resBody, reply)
+		if err != nil {
+			t.Fatalf("Could not parse reply to %s %s (%d): %v\n%s", method, path, res.StatusCode, err, resBody)
+		}
+	}
+	return res.StatusCode
+}
+
+func TestHTTPServices(t *testing.T) {
+	server, _ := newTestGateway(t)
+	pid := strconv.Itoa(os.Getpid())
+
+	list := &pb_core_messages.ServiceList{}
+	if status := doHTTPRequest(t, server, http.MethodGet, "/services", "", list); status != http.StatusOK {
+		t.Fatalf("Expected status 200 for the service list, got %d", status)
+	}
+	if len(list.Services) != 1 || list.Services[0].GetIdentifier().GetName() != "imaging" {
+		t.Errorf("Expected only the imaging service, got %v", list.Services)
+	}
+
+	for _, path := range []string{"/services/imaging", "/services/imaging?pid=" + pid} {
+		service := &pb_core_messages.Service{}
+		if status := doHTTPRequest(t, server, http.MethodGet, path, "", service); status != http.StatusOK {
+			t.Fatalf("Expected status 200 for %s, got %d", path, status)
+		}
+		if service.GetIdentifier().GetName() != "imaging" {
+			t.Errorf("Expected the imaging service for %s, got %v", path, service)
+		}
+	}
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/services/unknown", http.StatusNotFound},
+		{"/services/imaging?pid=1", http.StatusNotFound},
+		{"/services/imaging?pid=abc", http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		reply := &pb_core_messages.Error{}
+		if status := doHTTPRequest(t, server, http.MethodGet, test.path, "", reply); status != test.status {
+			t.Errorf("Expected status %d for %s, got %d", test.status, test.path, status)
+		}
+		if reply.Message == "" {
+			t.Errorf("Expected an error message for %s", test.path)
+		}
+	}
+}
+
+func TestHTTPTuning(t *testing.T) {
+	server, systemState := newTestGateway(t)
+
+	if status := doHTTPRequest(t, server, http.MethodGet, "/tuning", "", &pb_core_messages.TuningState{}); status != http.StatusOK {
+		t.Fatalf("Expected status 200 for the tuning state, got %d", status)
+	}
+
+	tuning := &pb_core_messages.TuningState{}
+	status := doHTTPRequest(t, server, http.MethodPut, "/tuning", `{"dynamicParameters": [{"int": {"key": "speed", "value": "4"}}]}`, tuning)
+	if status != http.StatusOK {
+		t.Fatalf("Expected status 200 for a valid tuning state, got %d", status)
+	}
+	if value := state.FindTuningParameter(tuning, "speed").GetInt().GetValue(); value != 4 {
+		t.Errorf("Expected speed 4 in the reply, got %d", value)
+	}
+
+	tuning = &pb_core_messages.TuningState{}
+	doHTTPRequest(t, server, http.MethodGet, "/tuning", "", tuning)
+	if value := state.FindTuningParameter(tuning, "speed").GetInt().GetValue(); value != 4 {
+		t.Errorf("Expected speed 4 after the update, got %d", value)
+	}
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"invalid JSON", `{"dynamicParameters": [`, http.StatusBadRequest},
+		{"unknown field", `{"parameters": []}`, http.StatusBadRequest},
+		{"oversized body", `{"dynamicParameters": []}` + strings.Repeat(" ", maxHTTPBodySize), http.StatusRequestEntityTooLarge},
+	}
+	for _, test := range tests {
+		reply := &pb_core_messages.Error{}
+		if status := doHTTPRequest(t, server, http.MethodPut, "/tuning", test.body, reply); status != test.status {
+			t.Errorf("Expected status %d for %s, got %d", test.status, test.name, status)
+		}
+		if reply.Message == "" {
+			t.Errorf("Expected an error message for %s", test.name)
+		}
+	}
+
+	// A violated constraint is answered with the details of the violation
+	detailed := &pb_core_extensions.DetailedError{}
+	status = doHTTPRequest(t, server, http.MethodPut, "/tuning", `{"dynamicParameters": [{"int": {"key": "speed", "value": "20"}}]}`, detailed)
+	if status != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status 422 for a violated constraint, got %d", status)
+	}
+	if len(detailed.TuningViolations) != 1 || detailed.TuningViolations[0].Key != "speed" {
+		t.Errorf("Expected a violation of speed, got %v", detailed.TuningViolations)
+	}
+
+	// None of the rejected updates may have changed the tuning state
+	if revision := systemState.GetTuningRevisionNumber(); revision != 1 {
+		t.Errorf("Expected tuning revision 1, got %d", revision)
+	}
+}
+
+// PUT /tuning has no base revision, so a conflict can only come from the other tuning operations
+func TestHTTPErrorReplyConflict(t *testing.T) {
+	current := &pb_core_extensions.TuningRevision{Revision: 3}
+	status, reply := httpErrorReply(&state.TuningConflictError{BaseRevision: 2, Current: current})
+	if status != http.StatusConflict {
+		t.Errorf("Expected status 409 for a conflict, got %d", status)
+	}
+	detailed, ok := reply.(*pb_core_extensions.DetailedError)
+	if !ok || detailed.GetCurrentTuning().GetRevision() != 3 {
+		t.Errorf("Expected a DetailedError with the current revision, got %v", reply)
+	}
+}
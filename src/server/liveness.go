@@ -0,0 +1,17 @@
+package server
+
+import (
+	"time"
+	"vu/ase/core/src/state"
+)
+
+// How often the leases of services are checked. A service is marked unresponsive at most this long after its lease expired
+const leaseCheckInterval = 250 * time.Millisecond
+
+// Periodically checks the leases of all services, the state broadcasts every service that becomes unresponsive or is removed because of it
+func watchLeases(state *state.State) {
+	for {
+		time.Sleep(leaseCheckInterval)
+		state.CheckLeases(time.Now())
+	}
+}
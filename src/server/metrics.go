@@ -0,0 +1,171 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+	"vu/ase/core/src/state"
+
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	pb_core_messages "github.com/VU-ASE/rovercom/packages/go/core"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
+)
+
+// Core uses its own registry, so that only its own metrics (and those of the Go runtime) are exposed
+var metricsRegistry = prometheus.NewRegistry()
+var metrics = promauto.With(metricsRegistry)
+
+var (
+	requestsReceived = metrics.NewCounter(prometheus.CounterOpts{
+		Name: "core_requests_received_total",
+		Help: "Requests received by the req/rep server, including those that could not be handled",
+	})
+	requestQueueLength = metrics.NewGauge(prometheus.GaugeOpts{
+		Name: "core_request_queue_length",
+		Help: "Requests that are waiting for a worker",
+	})
+	requestsRejected = metrics.NewCounter(prometheus.CounterOpts{
+		Name: "core_requests_rejected_total",
+		Help: "Requests that were turned away because all workers were busy and the request queue was full",
+	})
+	requestTimeouts = metrics.NewCounter(prometheus.CounterOpts{
+		Name: "core_request_timeouts_total",
+		Help: "Requests that timed out before a worker could handle them",
+	})
+	requestsHandled = metrics.NewCounterVec(prometheus.CounterOpts{
+		Name: "core_requests_total",
+		Help: "Requests handled, by message type",
+	}, []string{"type"})
+	requestErrors = metrics.NewCounterVec(prometheus.CounterOpts{
+		Name: "core_request_errors_total",
+		Help: "Requests that were answered with an error, by message type",
+	}, []string{"type"})
+	requestDuration = metrics.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "core_request_duration_seconds",
+		Help:    "Time spent handling requests, by message type",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"type"})
+	broadcastsSent = metrics.NewCounterVec(prometheus.CounterOpts{
+		Name: "core_broadcasts_total",
+		Help: "Broadcasts sent, by topic (without the service name or tuning key)",
+	}, []string{"topic"})
+	broadcastFailures = metrics.NewCounterVec(prometheus.CounterOpts{
+		Name: "core_broadcast_failures_total",
+		Help: "Broadcasts that could not be sent, by topic (without the service name or tuning key)",
+	}, []string{"topic"})
+)
+
+// Records a handled request
+func observeRequest(messageType string, duration time.Duration, err error) {
+	requestsHandled.WithLabelValues(messageType).Inc()
+	requestDuration.WithLabelValues(messageType).Observe(duration.Seconds())
+	if err != nil {
+		requestErrors.WithLabelValues(messageType).Inc()
+	}
+}
+
+// Records a broadcast. Service names and tuning keys are left out of the topic, they would make for too many different labels
+func observeBroadcast(topic string, err error) {
+	topic, _, _ = strings.Cut(topic, "/")
+	if err != nil {
+		broadcastFailures.WithLabelValues(topic).Inc()
+		return
+	}
+	broadcastsSent.WithLabelValues(topic).Inc()
+}
+
+// Returns the name of the message in the oneof of a request (such as "service" or "tuningStateUpsert"), used to label the metrics of the request
+func requestType(parsedMessage *pb_core_messages.CoreMessage, body []byte) string {
+	if field := parsedMessage.ProtoReflect().WhichOneof(parsedMessage.ProtoReflect().Descriptor().Oneofs().ByName("msg")); field != nil {
+		return string(field.Name())
+	}
+
+	extensionMessage := pb_core_extensions.CoreExtensionMessage{}
+	err := proto.Unmarshal(body, &extensionMessage)
+	if err == nil {
+		if field := extensionMessage.ProtoReflect().WhichOneof(extensionMessage.ProtoReflect().Descriptor().Oneofs().ByName("msg")); field != nil {
+			return string(field.Name())
+		}
+	}
+	return "unsupported"
+}
+
+// Reports the number of registered services (by status) and tuning parameters whenever the metrics are collected
+type stateCollector struct {
+	state      *state.State
+	services   *prometheus.Desc
+	parameters *prometheus.Desc
+}
+
+func newStateCollector(state *state.State) *stateCollector {
+	return &stateCollector{
+		state:      state,
+		services:   prometheus.NewDesc("core_services", "Registered services, by status", []string{"status"}, nil),
+		parameters: prometheus.NewDesc("core_tuning_parameters", "Parameters in the tuning state", nil, nil),
+	}
+}
+
+func (c *stateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.services
+	ch <- c.parameters
+}
+
+func (c *stateCollector) Collect(ch chan<- prometheus.Metric) {
+	// Every status is reported, also when no service has it. Services with an extended status are counted under that status instead
+	counts := make(map[string]int)
+	for _, name := range pb_core_messages.ServiceStatus_name {
+		counts[name] = 0
+	}
+	for status, name := range pb_core_extensions.ExtendedServiceStatus_name {
+		if status != int32(pb_core_extensions.ExtendedServiceStatus_NO_EXTENDED_STATUS) {
+			counts[name] = 0
+		}
+	}
+	for _, s := range c.state.GetServices() {
+		if extended := c.state.GetExtendedStatus(s); extended != pb_core_extensions.ExtendedServiceStatus_NO_EXTENDED_STATUS {
+			counts[extended.String()]++
+		} else {
+			counts[s.Status.String()]++
+		}
+	}
+	for status, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.services, prometheus.GaugeValue, float64(count), status)
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.parameters, prometheus.GaugeValue, float64(len(c.state.GetTuningState().GetDynamicParameters())))
+}
+
+// Starts serving the metrics of core in the Prometheus format on /metrics at the given address, in the background
+func StartMetricsServer(address string, state *state.State) error {
+	err := metricsRegistry.Register(newStateCollector(state))
+	if err != nil {
+		return err
+	}
+	metricsRegistry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("Could not start metrics server on '%s': %v", address, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{}))
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Info().Str("address", listener.Addr().String()).Msg("Serving metrics")
+	go func() {
+		err := server.Serve(listener)
+		log.Err(err).Msg("Metrics server stopped")
+	}()
+	return nil
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"vu/ase/core/src/procutils"
+	"vu/ase/core/src/services"
+	"vu/ase/core/src/state"
+	"vu/ase/core/src/supervisor"
+
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	pb_core_messages "github.com/VU-ASE/rovercom/packages/go/core"
+	"github.com/rs/zerolog/log"
+)
+
+// Looks up the service that the order is meant for, checks the token against it and carries out the order
+func applyServiceOrder(msg *pb_core_messages.ServiceOrder, requestedService *pb_core_messages.ServiceIdentifier, token string, systemState *state.State) (*pb_core_messages.Service, error) {
+	// A pid of 0 means that the order applies to whichever process is registered under this name (the first instance, if there are several)
+	s := systemState.GetService(requestedService.Name)
+	if requestedService.Pid != 0 {
+		s = systemState.GetServiceByIdentifier(requestedService.Name, requestedService.Pid)
+	}
+	if s == nil && msg.Order == pb_core_messages.ServiceOrder_FORCE_RESTART {
+		// Crash-looping services are no longer registered, but can still be brought back
+		process := systemState.GetManagedProcess(requestedService.Name)
+		if process != nil && process.CrashLooping {
+			return relaunchService(process, nil, systemState)
+		}
+	}
+	if s == nil || (requestedService.Pid != 0 && s.Identifier.Pid != requestedService.Pid) {
+		log.Warn().Str("service", requestedService.Name).Int32("pid", requestedService.Pid).Msg("Received service order for unregistered service")
+		return nil, fmt.Errorf("Could not apply order %s: service '%s' (pid %d) is not registered", msg.Order.String(), requestedService.Name, requestedService.Pid)
+	}
+	err := systemState.Authenticate(s.Identifier.Name, s.Identifier.Pid, token)
+	if err != nil {
+		log.Warn().Str("service", s.Identifier.Name).Int32("pid", s.Identifier.Pid).Msg("Rejected unauthenticated service order")
+		return nil, fmt.Errorf("Could not apply order %s: %v", msg.Order.String(), err)
+	}
+	if int(s.Identifier.Pid) == os.Getpid() {
+		return nil, fmt.Errorf("Could not apply order %s: service '%s' is the core itself", msg.Order.String(), s.Identifier.Name)
+	}
+
+	switch msg.Order {
+	case pb_core_messages.ServiceOrder_STOP:
+		return stopService(s, systemState, false)
+	case pb_core_messages.ServiceOrder_KILL:
+		return stopService(s, systemState, true)
+	case pb_core_messages.ServiceOrder_FORCE_RESTART:
+		return restartService(s, systemState)
+	default:
+		return nil, fmt.Errorf("Could not apply order %s to service '%s': unknown order type", msg.Order.String(), s.Identifier.Name)
+	}
+}
+
+// Stops the process of a registered service and removes it from the list of services.
+// If force is set, the process is killed immediately instead of being asked to shut down
+func stopService(service *pb_core_messages.Service, systemState *state.State, force bool) (*pb_core_messages.Service, error) {
+	err := supervisor.Stop(service.Identifier.Name, int(service.Identifier.Pid), force, systemState)
+	if err != nil {
+		return nil, err
+	}
+
+	systemState.RemoveService(service.Identifier.Name, service.Identifier.Pid, pb_core_extensions.ServiceEvent_EXPLICIT_STOP)
+	service.Status = pb_core_messages.ServiceStatus_STOPPED
+	return service, nil
+}
+
+// Kills the process of a registered service and starts it again with the same command. From then on, core manages the process
+// and will stop it when core terminates. The new process is expected to register itself, so the returned service is not registered yet
+func restartService(service *pb_core_messages.Service, systemState *state.State) (*pb_core_messages.Service, error) {
+	name := service.Identifier.Name
+	pid := int(service.Identifier.Pid)
+
+	// If we started the process ourselves, we know how to start it again. Otherwise we need to find out
+	// before killing the process, because its /proc entry is gone afterwards
+	process := systemState.GetManagedProcess(name)
+	if process == nil || process.Pid != pid {
+		command, err := procutils.GetProcessCommand(pid)
+		if err != nil {
+			return nil, fmt.Errorf("Could not restart service '%s' (pid %d): failed to read its command: %v", name, pid, err)
+		}
+		process = &state.ManagedProcess{
+			Name:          name,
+			Command:       command,
+			RestartPolicy: state.RestartPolicy{Mode: state.RestartNever},
+			Dependencies:  services.DependencyNames(service),
+		}
+	}
+
+	_, err := stopService(service, systemState, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return relaunchService(process, service, systemState)
+}
+
+// Starts a managed process again, after it was stopped or gave up because it was crash-looping.
+// The returned service is built from the last known registration (if any)
+func relaunchService(process *state.ManagedProcess, lastKnown *pb_core_messages.Service, systemState *state.State) (*pb_core_messages.Service, error) {
+	oldPid := process.Pid
+
+	// An explicit restart gives the process a clean slate
+	process.Exits = nil
+	systemState.UpdateManagedProcess(process.Name, func(p *state.ManagedProcess) {
+		p.Exits = nil
+	})
+	err := supervisor.Launch(process, systemState)
+	if err != nil {
+		return nil, fmt.Errorf("Service '%s' was stopped, but could not be started again: %v", process.Name, err)
+	}
+	log.Info().Str("service", process.Name).Int("oldPid", oldPid).Int("pid", process.Pid).Msg("Restarted service")
+
+	res := &pb_core_messages.Service{
+		Identifier: &pb_core_messages.ServiceIdentifier{
+			Name: process.Name,
+			Pid:  int32(process.Pid),
+		},
+		Status: pb_core_messages.ServiceStatus_NOT_REGISTERED,
+	}
+	if lastKnown != nil {
+		res.Endpoints = lastKnown.Endpoints
+		res.Options = lastKnown.Options
+		res.Dependencies = lastKnown.Dependencies
+	}
+	return res, nil
+}
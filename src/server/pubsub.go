@@ -1,6 +1,12 @@
 package server
 
 import (
+	"encoding/binary"
+	"sync"
+	"vu/ase/core/src/state"
+
+	pb_core_extensions "vu/ase/core/src/extensions"
+
 	pb_systemmanager_messages "github.com/VU-ASE/rovercom/packages/go/core"
 	zmq "github.com/pebbe/zmq4"
 	"github.com/rs/zerolog/log"
@@ -17,7 +23,24 @@ func SetupBroadcast(address string) (*zmq.Socket, error) {
 	return publisher, err
 }
 
-func BroadcastMessage(publisher *zmq.Socket, message *pb_systemmanager_messages.CoreMessage) error {
+// ZeroMQ sockets must not be used from more than one goroutine at a time, but broadcasts are sent from the request handlers
+// as well as from background workers (lease checks, the supervisor). The lock also guards the sequence number
+var publisherLock sync.Mutex
+
+// The sequence number of the latest broadcast
+var broadcastSequence uint64
+
+// Returns the sequence number of the latest broadcast, or 0 if nothing was broadcast yet
+func BroadcastSequence() uint64 {
+	publisherLock.Lock()
+	defer publisherLock.Unlock()
+
+	return broadcastSequence
+}
+
+// Sends the message under the given topic (see the topics in the extensions package) with the next sequence number.
+// Subscribers that expect a single frame fail to parse the topic and sequence frames and can skip them
+func BroadcastMessage(publisher *zmq.Socket, topic string, message *pb_core_extensions.CoreExtensionMessage) error {
 	if publisher == nil {
 		log.Warn().Msg("Was asked to broadcast a message, but no publisher was set up. Ignoring.")
 		return nil
@@ -25,10 +48,76 @@ func BroadcastMessage(publisher *zmq.Socket, message *pb_systemmanager_messages.
 
 	// Marshal the message
 	messageBytes, err := proto.Marshal(message)
+	if err != nil {
+		observeBroadcast(topic, err)
+		return err
+	}
+
+	publisherLock.Lock()
+	defer publisherLock.Unlock()
+	sequence := binary.BigEndian.AppendUint64(nil, broadcastSequence+1)
+	_, err = publisher.SendMessage(topic, sequence, messageBytes)
+	observeBroadcast(topic, err)
+	if err != nil {
+		return err
+	}
+	broadcastSequence++
+	recordBroadcast([]byte(topic), sequence, messageBytes)
+
+	// Browsers cannot subscribe to the publisher, so the broadcast is relayed to the WebSocket clients as well
+	relayBroadcast(topic, broadcastSequence, message)
+	return nil
+}
+
+// Broadcasts a service under its own topic, used when it registers
+func BroadcastService(publisher *zmq.Socket, service *pb_systemmanager_messages.Service) error {
+	return BroadcastMessage(publisher, pb_core_extensions.ServiceTopic(service.GetIdentifier().GetName()), &pb_core_extensions.CoreExtensionMessage{
+		Msg: &pb_core_extensions.CoreExtensionMessage_Service{
+			Service: service,
+		},
+	})
+}
+
+// Broadcasts a status change or removal of a service under its own topic as well as the status topic
+func BroadcastServiceEvent(publisher *zmq.Socket, event *pb_core_extensions.ServiceEvent) error {
+	message := &pb_core_extensions.CoreExtensionMessage{
+		Msg: &pb_core_extensions.CoreExtensionMessage_ServiceEvent{
+			ServiceEvent: event,
+		},
+	}
+	err := BroadcastMessage(publisher, pb_core_extensions.ServiceTopic(event.GetService().GetIdentifier().GetName()), message)
+	if err != nil {
+		return err
+	}
+	return BroadcastMessage(publisher, pb_core_extensions.TopicStatus, message)
+}
+
+// Broadcasts the full tuning state of a revision under the tuning topic, and every parameter that changed in it under its own topic
+func BroadcastTuningRevision(publisher *zmq.Socket, revision *pb_core_extensions.TuningRevision) error {
+	err := BroadcastMessage(publisher, pb_core_extensions.TopicTuning, &pb_core_extensions.CoreExtensionMessage{
+		Msg: &pb_core_extensions.CoreExtensionMessage_TuningState{
+			TuningState: revision.State,
+		},
+	})
 	if err != nil {
 		return err
 	}
 
-	_, err = publisher.SendBytes(messageBytes, 0)
-	return err
+	for _, key := range revision.ChangedKeys {
+		err = BroadcastMessage(publisher, pb_core_extensions.TuningKeyTopic(key), &pb_core_extensions.CoreExtensionMessage{
+			Msg: &pb_core_extensions.CoreExtensionMessage_TuningParameterUpdate{
+				TuningParameterUpdate: &pb_core_extensions.TuningParameterUpdate{
+					Key: key,
+					// Not set if the parameter was removed
+					Parameter: state.FindTuningParameter(revision.State, key),
+					Revision:  revision.Revision,
+					Timestamp: revision.State.GetTimestamp(),
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"os"
+	"time"
+
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	"github.com/rs/zerolog/log"
+)
+
+// The recording that every broadcast is written to, if one was started. Guarded by publisherLock
+var recording *pb_core_extensions.RecordingWriter
+var recordingFile *os.File
+
+// Starts recording every broadcast to the given file (see the recording format in the extensions package), replacing what it contained
+func StartRecording(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	writer, err := pb_core_extensions.NewRecordingWriter(file)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	publisherLock.Lock()
+	defer publisherLock.Unlock()
+	recording = writer
+	recordingFile = file
+	log.Info().Str("path", path).Msg("Recording broadcasts")
+	return nil
+}
+
+// Stops the recording, if one was started
+func StopRecording() error {
+	publisherLock.Lock()
+	defer publisherLock.Unlock()
+
+	if recordingFile == nil {
+		return nil
+	}
+	err := recordingFile.Close()
+	recording = nil
+	recordingFile = nil
+	return err
+}
+
+// Writes a broadcast that was just sent to the recording. The caller must hold publisherLock. If the recording cannot be
+// written to, it is stopped, rather than complaining about every broadcast after
+func recordBroadcast(frames ...[]byte) {
+	if recording == nil {
+		return
+	}
+
+	err := recording.Write(pb_core_extensions.RecordedBroadcast{
+		Time:   time.Now(),
+		Frames: frames,
+	})
+	if err != nil {
+		log.Err(err).Str("path", recordingFile.Name()).Msg("Failed to record broadcast, stopped recording")
+		recordingFile.Close()
+		recording = nil
+		recordingFile = nil
+	}
+}
@@ -1,12 +1,13 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"time"
-	"vu/ase/core/src/procutils"
-	"vu/ase/core/src/services"
 	"vu/ase/core/src/state"
 
+	pb_core_extensions "vu/ase/core/src/extensions"
+
 	pb_core_messages "github.com/VU-ASE/rovercom/packages/go/core"
 
 	zmq "github.com/pebbe/zmq4"
@@ -16,8 +17,10 @@ import (
 
 // Sets up the registration server (based on a req-rep client-server model). Other services can register themselves by making a request to
 // this server, or request service statusses and tuning parameters.
-func Serve(addr string, state *state.State) error {
-	server, err := zmq.NewSocket(zmq.REP)
+// Requests arrive on a ROUTER socket and are handed to a pool of workers, so that one slow request does not hold up the others.
+// To clients, this looks exactly like a REP socket: every request gets exactly one reply
+func Serve(addr string, workers int, requestTimeout time.Duration, state *state.State) error {
+	server, err := zmq.NewSocket(zmq.ROUTER)
 	if err != nil {
 		log.Err(err).Msg("Failed to create server socket")
 		return err
@@ -28,6 +31,18 @@ func Serve(addr string, state *state.State) error {
 	}
 	defer server.Close()
 
+	// ZeroMQ sockets cannot be shared between goroutines, so the workers pass their replies back over their own sockets
+	replies, err := zmq.NewSocket(zmq.PULL)
+	if err != nil {
+		log.Err(err).Msg("Failed to create reply socket")
+		return err
+	}
+	err = replies.Bind(repliesAddr)
+	if err != nil {
+		return err
+	}
+	defer replies.Close()
+
 	// This goroutine will periodically check if services are still running, and clean them up if not
 	go func() {
 		for {
@@ -37,62 +52,109 @@ func Serve(addr string, state *state.State) error {
 		}
 	}()
 
-	// Main receiver loop
+	// Services with a lease are checked more often, since their lease can be much shorter
+	go watchLeases(state)
+
+	// Idle workers pick the next request from here, so a request never waits for a busy worker while another one is idle
+	requests := make(chan *Request, requestQueueSize)
+	for i := 0; i < max(workers, 1); i++ {
+		w, err := newWorker(i, state)
+		if err != nil {
+			return err
+		}
+		go w.run(requests)
+	}
+	log.Info().Str("address", addr).Int("workers", workers).Str("timeout", requestTimeout.String()).Msg("Serving requests")
+
+	poller := zmq.NewPoller()
+	poller.Add(server, zmq.POLLIN)
+	poller.Add(replies, zmq.POLLIN)
+
+	// Main receiver loop, passes requests to the workers and their replies back to the clients
 	for {
-		// Receive request
-		msg, err := server.RecvBytes(0)
+		polled, err := poller.Poll(-1)
 		if err != nil {
-			log.Err(err).Msg("Failed to receive request")
+			log.Err(err).Msg("Failed to poll server sockets")
 			continue
-		} else {
-			log.Debug().Msg("Received request")
-
-			res, err := handleMessage(msg, state)
-			if err != nil {
-				log.Err(err).Msg("Failed to handle message")
-
-				// Send the error in a special error object that the client can handle
-				errMsg, err := proto.Marshal(&pb_core_messages.CoreMessage{
-					Msg: &pb_core_messages.CoreMessage_Error{
-						Error: &pb_core_messages.Error{
-							Message: err.Error(),
-						},
-					},
-				})
+		}
+
+		for _, p := range polled {
+			switch p.Socket {
+			case server:
+				// Receive request, which is preceded by the frames that the server socket needs to route the reply back to the client
+				frames, err := server.RecvMessageBytes(0)
 				if err != nil {
-					log.Err(err).Msg("Failed to marshal error message")
-					// Best-effort, we send a string so that the client has *a* reply and can continue, but the client probably does not know how to handle it
-					_, _ = server.SendMessage("Failed to marshal error message")
-				} else {
-					// Error object was created and marshalled, send it
-					_, _ = server.SendBytes(errMsg, 0)
+					log.Err(err).Msg("Failed to receive request")
+					continue
 				}
-
-			} else {
-				// Try to marshal the response and send it
-				resBytes, err := proto.Marshal(res)
+				requestsReceived.Inc()
+				if len(frames) < 2 {
+					log.Warn().Msg("Received request without a client identity, dropping it")
+					continue
+				}
+				log.Debug().Msg("Received request")
+
+				// The timeout includes the time spent waiting for a worker
+				ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+				request := &Request{
+					Envelope:       frames[:len(frames)-1],
+					ClientIdentity: frames[0],
+					Body:           frames[len(frames)-1],
+					Context:        ctx,
+					cancel:         cancel,
+				}
+				select {
+				case requests <- request:
+					requestQueueLength.Set(float64(len(requests)))
+				default:
+					// Waiting for room in the queue would also hold up the replies of the workers, so the client is turned away instead
+					rejectRequest(server, request)
+				}
+			case replies:
+				frames, err := replies.RecvMessageBytes(0)
+				if err != nil {
+					log.Err(err).Msg("Failed to receive reply from worker")
+					continue
+				}
+				_, err = server.SendMessage(frames)
 				if err != nil {
-					log.Err(err).Msg("Failed to marshal response")
-					// Best-effort, we send a string so that the client has *a* reply and can continue, but the client probably does not know how to handle it
-					_, _ = server.SendMessage("Failed to marshal response")
-				} else {
-					log.Debug().Msg("Sending response")
-					_, _ = server.SendBytes(resBytes, 0)
+					log.Err(err).Msg("Failed to send response")
 				}
 			}
 		}
 	}
+}
+
+// Answers a request that could not be queued with an error, so that the client can try again later
+func rejectRequest(server *zmq.Socket, request *Request) {
+	defer request.cancel()
 
+	log.Warn().Str("client", request.Client()).Msg("All workers are busy and the request queue is full, rejecting request")
+	requestsRejected.Inc()
+	reply, err := proto.Marshal(errorReply(fmt.Errorf("Core is too busy to handle the request, try again later")))
+	if err == nil {
+		_, err = server.SendMessage(request.Envelope, reply)
+	}
+	if err != nil {
+		log.Err(err).Str("client", request.Client()).Msg("Failed to reject request")
+	}
 }
 
 // Handles a message received by the server, and returns response message that should be send back to the client
-func handleMessage(msg []byte, state *state.State) (*pb_core_messages.CoreMessage, error) {
+func handleMessage(request *Request, state *state.State) (res proto.Message, err error) {
+	start := time.Now()
+	messageType := "invalid"
+	defer func() {
+		observeRequest(messageType, time.Since(start), err)
+	}()
+
 	// Unmarshal the wrapper
 	parsedMessage := pb_core_messages.CoreMessage{}
-	err := proto.Unmarshal(msg, &parsedMessage)
+	err = proto.Unmarshal(request.Body, &parsedMessage)
 	if err != nil {
 		return handleUnsupported()
 	}
+	messageType = requestType(&parsedMessage, request.Body)
 
 	// Let's see what we're dealing with
 	switch {
@@ -126,7 +188,7 @@ func handleMessage(msg []byte, state *state.State) (*pb_core_messages.CoreMessag
 		}
 	case parsedMessage.GetTuningState() != nil:
 		{
-			res, err := handleTuningStateUpsert(parsedMessage.GetTuningState(), state)
+			res, err := handleTuningStateUpsert(parsedMessage.GetTuningState(), request.Identity(), state)
 			return &pb_core_messages.CoreMessage{
 				Msg: &pb_core_messages.CoreMessage_TuningState{
 					TuningState: res,
@@ -153,11 +215,17 @@ func handleMessage(msg []byte, state *state.State) (*pb_core_messages.CoreMessag
 		}
 	case parsedMessage.GetServiceOrder() != nil:
 		{
-			return handleUnimplemented()
+			res, err := handleServiceOrder(parsedMessage.GetServiceOrder(), "", state)
+			return &pb_core_messages.CoreMessage{
+				Msg: &pb_core_messages.CoreMessage_Service{
+					Service: res,
+				},
+			}, err
 		}
 	default:
 		{
-			return handleUnsupported()
+			// Not one of the rovercom messages, but it might be one of the messages that core supports on top
+			return handleExtensionMessage(request, state)
 		}
 	}
 }
@@ -166,45 +234,33 @@ func handleMessage(msg []byte, state *state.State) (*pb_core_messages.CoreMessag
 // REQ-REP endpoint handlers
 //
 
-func handleServiceRegistration(msg *pb_core_messages.Service, state *state.State) (*pb_core_messages.Service, error) {
+func handleServiceRegistration(msg *pb_core_messages.Service, systemState *state.State) (*pb_core_messages.Service, error) {
 	log.Debug().Msg("[reqrep]: handling service registration")
 
-	// Clean up all services that are no longer active
-	state.UpdateServiceStatusses()
-
-	msg.Status = pb_core_messages.ServiceStatus_REGISTERED
-
-	// We can't register a service that is already registered (by name)
-	s := state.GetService(msg.Identifier.Name)
-	if s != nil && procutils.ProcessExists(int(s.Identifier.Pid)) {
-		log.Warn().Str("service", s.Identifier.Name).Int("pid", int(s.Identifier.Pid)).Msg("Attempted to register service that was already registered and is still active")
-		return nil, fmt.Errorf("Tried to register servicce '%s' but failed: this service is already registered and still running (running with PID %d) ", msg.Identifier.Name, s.Identifier.Pid)
-	}
-
-	// We can't register a service with tuning options that are already used by another service
-	for _, o := range msg.Options {
-		existingOption, existingService := state.GetServiceOption(o.Name)
-		if existingOption != nil && existingService != nil {
-			return nil, fmt.Errorf("Tried to register servicce '%s' but failed: the service option %s is already in use by service '%s'. Change the name of this option in the service.yaml of service '%s' or stop service '%s' (running with PID %d) ", msg.Identifier.Name, existingOption.Name, existingService.Identifier.Name, msg.Identifier.Name, existingService.Identifier.Name, existingService.Identifier.Pid)
-		}
+	registered, err := registerService(msg, state.Registration{}, systemState)
+	if err != nil {
+		return nil, err
 	}
+	return registered.Service, nil
+}
 
-	// The registration timestamp is necessary to fetch tuning states later
-	msg.RegisteredAt = time.Now().UnixMilli()
+// Registers a service with everything it asked for, and broadcasts it once it is completely registered
+func registerService(msg *pb_core_messages.Service, registration state.Registration, systemState *state.State) (*state.RegisteredService, error) {
+	// Clean up all services that are no longer active
+	systemState.UpdateServiceStatusses()
 
-	// Actually add to the list of services
-	state.AddService(msg)
+	// Checks whether the service can be registered (by name, options and dependencies) and adds it to the list of services
+	registered, err := systemState.RegisterService(msg, registration)
+	if err != nil {
+		return nil, err
+	}
 
 	// Broadcast the new service for everyone interested
-	err := BroadcastMessage(state.PublisherSocket, &pb_core_messages.CoreMessage{
-		Msg: &pb_core_messages.CoreMessage_Service{
-			Service: msg,
-		},
-	})
+	err = BroadcastService(systemState.PublisherSocket, registered.Service)
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to broadcast new service")
 	}
-	return msg, nil
+	return registered, nil
 }
 
 func handleServiceInformationRequest(msg *pb_core_messages.ServiceInformationRequest, state *state.State) *pb_core_messages.Service {
@@ -224,6 +280,17 @@ func handleServiceInformationRequest(msg *pb_core_messages.ServiceInformationReq
 
 	service := state.GetService(requestedService.Name)
 	if service == nil {
+		// A managed process can crash-loop before it ever registered, it is reported as stopped like in the broadcast about it
+		if process := state.GetManagedProcess(requestedService.Name); process != nil && process.CrashLooping {
+			return &pb_core_messages.Service{
+				Identifier: &pb_core_messages.ServiceIdentifier{
+					Name: process.Name,
+					Pid:  int32(process.Pid),
+				},
+				Status: pb_core_messages.ServiceStatus_STOPPED,
+			}
+		}
+
 		log.Warn().Str("service", requestedService.Name).Msg("Received service information request for unregistered service")
 		return &pb_core_messages.Service{
 			Identifier: requestedService,
@@ -232,7 +299,7 @@ func handleServiceInformationRequest(msg *pb_core_messages.ServiceInformationReq
 	}
 
 	// we found the service, get the status
-	status := services.ServiceStatus(service)
+	status := state.GetServiceStatus(service)
 	service.Status = status
 	return service
 }
@@ -240,32 +307,76 @@ func handleServiceInformationRequest(msg *pb_core_messages.ServiceInformationReq
 func handleServiceStatusUpdate(msg *pb_core_messages.ServiceStatusUpdate, state *state.State) (*pb_core_messages.Service, error) {
 	log.Debug().Msg("[reqrep]: handling service status update")
 
-	//! there is no actual check if the sender is actually the service that is being updated
+	if msg.Service == nil {
+		return nil, fmt.Errorf("Received service status update without a service")
+	}
+
+	// A plain status update carries no token, so it is only accepted for services that registered without a session.
+	// Services that did register with a session must send an AuthenticatedStatusUpdate instead
+	err := state.Authenticate(msg.Service.Name, msg.Service.Pid, "")
+	if err != nil {
+		log.Warn().Str("service", msg.Service.Name).Int32("pid", msg.Service.Pid).Msg("Rejected unauthenticated status update")
+		return nil, fmt.Errorf("Could not update status of service '%s': %v", msg.Service.Name, err)
+	}
 	return state.UpdateServiceStatus(msg.Service.Name, msg.Service.Pid, msg.Status)
 }
 
-func handleTuningStateUpsert(msg *pb_core_messages.TuningState, state *state.State) (*pb_core_messages.TuningState, error) {
+// The token must be the one of the service that the order is for if it registered with a session, and empty otherwise.
+// A plain ServiceOrder carries no token, so services that registered with a session only accept an AuthenticatedServiceOrder
+func handleServiceOrder(msg *pb_core_messages.ServiceOrder, token string, state *state.State) (*pb_core_messages.Service, error) {
+	log.Debug().Msg("[reqrep]: handling service order")
+
+	requestedService := msg.GetService()
+	if requestedService == nil {
+		log.Warn().Msg("Received service order without service")
+		return nil, fmt.Errorf("Received service order %s without a service to apply it to", msg.Order.String())
+	}
+
+	res, err := applyServiceOrder(msg, requestedService, token, state)
+	if err != nil {
+		log.Err(err).Str("service", requestedService.Name).Str("order", msg.Order.String()).Msg("Failed to apply service order")
+		return nil, err
+	}
+
+	// The state broadcasts the removal of a stopped service, but the process that replaces a restarted one
+	// is not registered (yet), so that is broadcast here
+	if msg.Order == pb_core_messages.ServiceOrder_FORCE_RESTART {
+		err = BroadcastService(state.PublisherSocket, res)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to broadcast service order outcome")
+		}
+	}
+	return res, nil
+}
+
+// The client is the identity that is recorded in the audit log for the changes, it may be empty
+func handleTuningStateUpsert(msg *pb_core_messages.TuningState, client string, systemState *state.State) (*pb_core_messages.TuningState, error) {
 	log.Debug().Msg("[reqrep]: handling tuning state upsert")
 
-	mergedTuning := state.UpdateTuningState(msg)
-	if mergedTuning == nil {
-		log.Warn().Msg("Failed to upsert tuning state")
-		return nil, fmt.Errorf("Failed to upsert tuning state")
+	revision, err := applyTuningUpdate(state.TuningUpdate{State: msg, Client: client}, systemState)
+	if err != nil {
+		return nil, err
 	}
+	return revision.State, nil
+}
 
-	log.Debug().Msgf("Tuning state updated, now has %d parameters", len(mergedTuning.DynamicParameters))
+// Applies a change to the tuning state and broadcasts the result
+func applyTuningUpdate(update state.TuningUpdate, systemState *state.State) (*pb_core_extensions.TuningRevision, error) {
+	revision, err := systemState.ApplyTuningUpdate(update)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to upsert tuning state")
+		return nil, err
+	}
+
+	log.Debug().Msgf("Tuning state updated, now has %d parameters", len(revision.State.DynamicParameters))
 
-	// Broadcast the new tuning state for everyone interested
-	err := BroadcastMessage(state.PublisherSocket, &pb_core_messages.CoreMessage{
-		Msg: &pb_core_messages.CoreMessage_TuningState{
-			TuningState: mergedTuning,
-		},
-	})
+	// Broadcast the new tuning state and the changed parameters for everyone interested
+	err = BroadcastTuningRevision(systemState.PublisherSocket, revision)
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to broadcast new tuning state")
 	}
 
-	return mergedTuning, nil
+	return revision, nil
 }
 
 func handleTuningStateRequest(state *state.State) (*pb_core_messages.TuningState, error) {
@@ -283,7 +394,7 @@ func handleServiceListRequest(state *state.State) (*pb_core_messages.ServiceList
 	log.Debug().Msg("[reqrep]: handling service list request")
 
 	state.UpdateServiceStatusses()
-	services := state.Services
+	services := state.GetServices()
 	if services == nil {
 		log.Warn().Msg("Received service list request message, but no services were found")
 		return nil, fmt.Errorf("No services found")
@@ -295,10 +406,6 @@ func handleServiceListRequest(state *state.State) (*pb_core_messages.ServiceList
 	}, nil
 }
 
-func handleUnimplemented() (*pb_core_messages.CoreMessage, error) {
-	return nil, fmt.Errorf("This endpoint is not implemented yet")
-}
-
-func handleUnsupported() (*pb_core_messages.CoreMessage, error) {
+func handleUnsupported() (proto.Message, error) {
 	return nil, fmt.Errorf("This endpoint is not supported, or you provided an unsupported message")
 }
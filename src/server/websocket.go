@@ -0,0 +1,154 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Broadcasts that a WebSocket client has not been sent yet. If a client falls this far behind, newer broadcasts are dropped
+// for it (which it can tell from the gap in sequence numbers)
+const websocketQueueSize = 256
+
+// Clients that do not answer a ping within this time are disconnected
+const websocketPingInterval = 30 * time.Second
+const websocketWriteTimeout = 10 * time.Second
+
+// A broadcast as it is sent to WebSocket clients, with the message in protojson
+type websocketBroadcast struct {
+	Topic    string          `json:"topic"`
+	Sequence uint64          `json:"sequence"`
+	Message  json.RawMessage `json:"message"`
+}
+
+type websocketClient struct {
+	// The topic prefixes that the client subscribed to, it receives everything if there are none
+	topics []string
+	queue  chan []byte
+}
+
+func (client *websocketClient) subscribedTo(topic string) bool {
+	if len(client.topics) == 0 {
+		return true
+	}
+	for _, prefix := range client.topics {
+		if strings.HasPrefix(topic, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// The WebSocket clients that broadcasts are relayed to
+var websocketClients = make(map[*websocketClient]bool)
+var websocketClientsLock sync.Mutex
+
+var websocketUpgrader = websocket.Upgrader{
+	// The bridge is meant for dashboards served from anywhere, and it only hands out what is broadcast to everyone anyway
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// Queues a broadcast for all WebSocket clients that subscribed to its topic. Never blocks, so that slow clients cannot hold up broadcasts
+func relayBroadcast(topic string, sequence uint64, message *pb_core_extensions.CoreExtensionMessage) {
+	websocketClientsLock.Lock()
+	defer websocketClientsLock.Unlock()
+
+	if len(websocketClients) == 0 {
+		return
+	}
+
+	messageJSON, err := protojson.Marshal(message)
+	if err != nil {
+		log.Warn().Err(err).Msg("Could not marshal broadcast for WebSocket clients")
+		return
+	}
+	frame, err := json.Marshal(websocketBroadcast{
+		Topic:    topic,
+		Sequence: sequence,
+		Message:  messageJSON,
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("Could not marshal broadcast for WebSocket clients")
+		return
+	}
+
+	for client := range websocketClients {
+		if !client.subscribedTo(topic) {
+			continue
+		}
+		select {
+		case client.queue <- frame:
+		default:
+			log.Warn().Str("topic", topic).Uint64("sequence", sequence).Msg("WebSocket client is falling behind, dropped broadcast")
+		}
+	}
+}
+
+// Upgrades the request to a WebSocket connection and relays broadcasts to it until the client disconnects.
+// Clients can pass one or more topic prefixes (see the topics in the extensions package) as ?topic=, to only receive those
+func handleWebsocketBroadcasts(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocketUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader already answered the request
+		log.Warn().Err(err).Msg("Could not upgrade to WebSocket connection")
+		return
+	}
+	defer conn.Close()
+
+	client := &websocketClient{
+		topics: r.URL.Query()["topic"],
+		queue:  make(chan []byte, websocketQueueSize),
+	}
+	websocketClientsLock.Lock()
+	websocketClients[client] = true
+	websocketClientsLock.Unlock()
+	defer func() {
+		websocketClientsLock.Lock()
+		delete(websocketClients, client)
+		websocketClientsLock.Unlock()
+	}()
+	log.Info().Str("remote", r.RemoteAddr).Strs("topics", client.topics).Msg("WebSocket client connected")
+
+	// Clients do not send anything but control frames, but they need to be read to notice pongs and a closed connection
+	closed := make(chan struct{})
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(websocketPingInterval + websocketWriteTimeout))
+	})
+	go func() {
+		defer close(closed)
+		_ = conn.SetReadDeadline(time.Now().Add(websocketPingInterval + websocketWriteTimeout))
+		for {
+			_, _, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(websocketPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case frame := <-client.queue:
+			_ = conn.SetWriteDeadline(time.Now().Add(websocketWriteTimeout))
+			err = conn.WriteMessage(websocket.TextMessage, frame)
+		case <-ping.C:
+			err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(websocketWriteTimeout))
+		case <-closed:
+			log.Info().Str("remote", r.RemoteAddr).Msg("WebSocket client disconnected")
+			return
+		}
+		if err != nil {
+			log.Info().Err(err).Str("remote", r.RemoteAddr).Msg("WebSocket client disconnected")
+			return
+		}
+	}
+}
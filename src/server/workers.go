@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"unicode"
+	"vu/ase/core/src/state"
+
+	zmq "github.com/pebbe/zmq4"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
+)
+
+// The in-process address on which the server collects the replies of its workers
+const repliesAddr = "inproc://core-replies"
+
+// The number of requests that can wait for a worker. When it is full, new requests are answered with an error until a worker is available
+const requestQueueSize = 64
+
+// A request as received by the server
+type Request struct {
+	// The frames that the server socket needs to route the reply back to the client
+	Envelope [][]byte
+	// The routing identity of the client. The server socket assigns one to every client that did not set its own
+	ClientIdentity []byte
+	Body           []byte
+	// Done once the request has timed out. A request that times out before a worker picks it up is not handled, one that is
+	// already being handled is finished
+	Context context.Context
+	cancel  context.CancelFunc
+}
+
+// A readable name for the client that sent the request, used in logs
+func (request *Request) Client() string {
+	if len(request.ClientIdentity) == 0 {
+		return "unknown"
+	}
+	for _, r := range string(request.ClientIdentity) {
+		if !unicode.IsPrint(r) {
+			return hex.EncodeToString(request.ClientIdentity)
+		}
+	}
+	return string(request.ClientIdentity)
+}
+
+// The identity that the client set on its socket, or an empty string if it did not set one (the identities that
+// the server socket assigns itself start with a zero byte, which ZeroMQ does not allow clients to use)
+func (request *Request) Identity() string {
+	if len(request.ClientIdentity) == 0 || request.ClientIdentity[0] == 0 {
+		return ""
+	}
+	return request.Client()
+}
+
+// Handles one request at a time
+type worker struct {
+	id int
+	// Replies are sent here, to be passed on to the client by the server
+	socket *zmq.Socket
+	state  *state.State
+}
+
+func newWorker(id int, state *state.State) (*worker, error) {
+	socket, err := zmq.NewSocket(zmq.PUSH)
+	if err != nil {
+		return nil, err
+	}
+	err = socket.Connect(repliesAddr)
+	if err != nil {
+		socket.Close()
+		return nil, err
+	}
+	return &worker{
+		id:     id,
+		socket: socket,
+		state:  state,
+	}, nil
+}
+
+func (w *worker) run(requests <-chan *Request) {
+	defer w.socket.Close()
+
+	for request := range requests {
+		requestQueueLength.Set(float64(len(requests)))
+		log.Debug().Int("worker", w.id).Str("client", request.Client()).Msg("Handling request")
+
+		_, err := w.socket.SendMessage(request.Envelope, w.handle(request))
+		if err != nil {
+			log.Err(err).Int("worker", w.id).Str("client", request.Client()).Msg("Failed to pass on response")
+		}
+	}
+}
+
+// Handles the request and returns the marshalled reply. A request that timed out while waiting for a worker is answered with an error
+// and not handled at all. A handler that has started is never abandoned: the worker waits for it, so that the pool keeps bounding the
+// number of requests that are handled at once, and the client gets its actual outcome rather than an error for a change that is applied anyway
+func (w *worker) handle(request *Request) []byte {
+	defer request.cancel()
+
+	var res proto.Message
+	var err error
+	if request.Context.Err() != nil {
+		log.Warn().Int("worker", w.id).Str("client", request.Client()).Msg("Request timed out before it was handled")
+		requestTimeouts.Inc()
+		err = fmt.Errorf("Request was not handled in time")
+	} else {
+		res, err = handleMessage(request, w.state)
+		if request.Context.Err() != nil {
+			log.Warn().Int("worker", w.id).Str("client", request.Client()).Msg("Request took longer than the request timeout to handle")
+		}
+	}
+
+	if err != nil {
+		log.Err(err).Str("client", request.Client()).Msg("Failed to handle message")
+
+		// Send the error in a special error object that the client can handle
+		errMsg, err := proto.Marshal(errorReply(err))
+		if err != nil {
+			log.Err(err).Msg("Failed to marshal error message")
+			// Best-effort, we send a string so that the client has *a* reply and can continue, but the client probably does not know how to handle it
+			return []byte("Failed to marshal error message")
+		}
+		return errMsg
+	}
+
+	// Try to marshal the response
+	resBytes, err := proto.Marshal(res)
+	if err != nil {
+		log.Err(err).Msg("Failed to marshal response")
+		// Best-effort, we send a string so that the client has *a* reply and can continue, but the client probably does not know how to handle it
+		return []byte("Failed to marshal response")
+	}
+	log.Debug().Msg("Sending response")
+	return resBytes
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+)
+
+// Hosts in a bind address that mean "all interfaces", which a client cannot connect to
+var wildcardHosts = []string{"*", "0.0.0.0", "::", ""}
+
+// Rewrites a bind address (such as tcp://*:1337) into an address that clients can connect to. The wildcard host is replaced by
+// the advertised host if it is set, or by the address of one of the interfaces of this machine otherwise. Hosts that name an
+// interface (such as tcp://eth0:1337) are replaced by the address of that interface. Other addresses are returned unchanged
+func ConnectableAddress(address string, advertisedHost string) (string, error) {
+	scheme, hostPort, found := strings.Cut(address, "://")
+	if !found {
+		return "", fmt.Errorf("Address '%s' has no transport (such as tcp://)", address)
+	}
+	// Only tcp addresses have a host that can be resolved, ipc and inproc addresses are connectable as they are
+	if scheme != "tcp" {
+		return address, nil
+	}
+
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return "", fmt.Errorf("Address '%s' is not a valid tcp address: %v", address, err)
+	}
+
+	var connectHost string
+	if isWildcardHost(host) {
+		connectHost = advertisedHost
+		if connectHost == "" {
+			connectHost = defaultHost()
+		}
+	} else if iface, err := net.InterfaceByName(host); err == nil {
+		connectHost = interfaceHost(iface)
+		if connectHost == "" {
+			return "", fmt.Errorf("Address '%s' binds to interface %s, which has no address", address, host)
+		}
+	} else {
+		return address, nil
+	}
+
+	return scheme + "://" + net.JoinHostPort(connectHost, port), nil
+}
+
+func isWildcardHost(host string) bool {
+	return slices.Contains(wildcardHosts, host)
+}
+
+// The address of the first interface that is up and not a loopback interface, preferring IPv4. Falls back to the loopback address
+// if there is no such interface, which is still reachable for clients on the same machine
+func defaultHost() string {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return "127.0.0.1"
+	}
+
+	var ipv6Host string
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		host := interfaceHost(&iface)
+		if host == "" {
+			continue
+		}
+		if net.ParseIP(host).To4() != nil {
+			return host
+		}
+		if ipv6Host == "" {
+			ipv6Host = host
+		}
+	}
+	if ipv6Host != "" {
+		return ipv6Host
+	}
+	return "127.0.0.1"
+}
+
+// The address of the interface that clients can connect to (IPv4 if it has one), or an empty string if there is none
+func interfaceHost(iface *net.Interface) string {
+	addresses, err := iface.Addrs()
+	if err != nil {
+		return ""
+	}
+
+	var ipv6Host string
+	for _, a := range addresses {
+		ipNet, ok := a.(*net.IPNet)
+		// Link-local IPv6 addresses would need a zone to connect to
+		if !ok || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		if ipNet.IP.To4() != nil {
+			return ipNet.IP.String()
+		}
+		if ipv6Host == "" {
+			ipv6Host = ipNet.IP.String()
+		}
+	}
+	return ipv6Host
+}
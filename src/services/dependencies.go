@@ -0,0 +1,100 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	pb_systemmanager_messages "github.com/VU-ASE/rovercom/packages/go/core"
+)
+
+// Maps the name of each service to the names of the services that it depends on
+type DependencyGraph map[string][]string
+
+// Returns the (unique) names of the services that this service depends on
+func DependencyNames(service *pb_systemmanager_messages.Service) []string {
+	names := make([]string, 0)
+	for _, d := range service.GetDependencies() {
+		if d != nil && !slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, d.ServiceName) }) {
+			names = append(names, d.ServiceName)
+		}
+	}
+	return names
+}
+
+// Builds a dependency graph from a list of (registered) services
+func DependencyGraphFromServices(list []*pb_systemmanager_messages.Service) DependencyGraph {
+	graph := make(DependencyGraph)
+	for _, s := range list {
+		if s != nil && s.Identifier != nil {
+			graph[s.Identifier.Name] = DependencyNames(s)
+		}
+	}
+	return graph
+}
+
+// Returns the names of all services in the graph, ordered such that every service comes after the services that it depends on.
+// Dependencies on services that are not in the graph are ignored, they are not ours to order. Fails if the graph contains a cycle
+func (graph DependencyGraph) TopologicalOrder() ([]string, error) {
+	// Sort the names, so that the order is the same every time
+	names := make([]string, 0, len(graph))
+	for name := range graph {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	// Service names are case insensitive, like everywhere else in core
+	lookup := func(name string) (string, bool) {
+		for _, n := range names {
+			if strings.EqualFold(n, name) {
+				return n, true
+			}
+		}
+		return "", false
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	marks := make(map[string]int)
+	order := make([]string, 0, len(names))
+	path := make([]string, 0)
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch marks[name] {
+		case visited:
+			return nil
+		case visiting:
+			cycle := append(slices.Clone(path[slices.Index(path, name):]), name)
+			return fmt.Errorf("Dependency cycle between services: %s", strings.Join(cycle, " -> "))
+		}
+
+		marks[name] = visiting
+		path = append(path, name)
+		for _, d := range graph[name] {
+			dependency, ok := lookup(d)
+			if !ok {
+				continue
+			}
+			err := visit(dependency)
+			if err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		marks[name] = visited
+		order = append(order, name)
+		return nil
+	}
+
+	for _, name := range names {
+		err := visit(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
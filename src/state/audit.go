@@ -0,0 +1,91 @@
+package state
+
+import (
+	"slices"
+	"strconv"
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	pb_systemmanager_messages "github.com/VU-ASE/rovercom/packages/go/core"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
+)
+
+// The number of audit records that are kept in memory, older records are only kept in the audit file (if there is one)
+const MaxAuditRecords = 10000
+
+// Adds an audit record for every parameter that changed in the given revision. Records are appended to the audit file
+// (if there is one) right away, so that the trail survives a crash of core
+func (state *State) recordTuningAudit(revision *pb_core_extensions.TuningRevision, previous *pb_systemmanager_messages.TuningState, client string) {
+	records := make([]*pb_core_extensions.TuningAuditRecord, 0)
+	for _, c := range DiffTuningStates(previous, revision.State) {
+		log.Info().Str("key", c.Key).Str("old", formatParameterValue(c.Old)).Str("new", formatParameterValue(c.New)).Str("client", client).Uint64("revision", revision.Revision).Msg("Tuning parameter changed")
+		records = append(records, &pb_core_extensions.TuningAuditRecord{
+			Timestamp: revision.Timestamp,
+			Revision:  revision.Revision,
+			Client:    client,
+			Change:    proto.Clone(c).(*pb_core_extensions.TuningParameterChange),
+		})
+	}
+	if len(records) == 0 {
+		return
+	}
+
+	state.auditLog = append(state.auditLog, records...)
+	if len(state.auditLog) > MaxAuditRecords {
+		state.auditLog = slices.Delete(state.auditLog, 0, len(state.auditLog)-MaxAuditRecords)
+	}
+
+	if state.AuditLogPath != "" {
+		err := AppendAuditRecords(state.AuditLogPath, records)
+		if err != nil {
+			log.Err(err).Str("path", state.AuditLogPath).Msg("Failed to append to audit log")
+		}
+	}
+}
+
+// Replaces the audit records in memory with those of a previous run, as read by LoadAuditLog (oldest first)
+func (state *State) RestoreAuditLog(records []*pb_core_extensions.TuningAuditRecord) {
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	if len(records) > MaxAuditRecords {
+		records = records[len(records)-MaxAuditRecords:]
+	}
+	state.auditLog = make([]*pb_core_extensions.TuningAuditRecord, len(records))
+	for i, r := range records {
+		state.auditLog[i] = proto.Clone(r).(*pb_core_extensions.TuningAuditRecord)
+	}
+}
+
+// Returns the audit records (oldest first) of the given key, or of all keys if it is empty, with a timestamp between from and to.
+// A zero from or to means that there is no bound on that side
+func (state *State) GetTuningAudit(key string, from uint64, to uint64) []*pb_core_extensions.TuningAuditRecord {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	records := make([]*pb_core_extensions.TuningAuditRecord, 0)
+	for _, r := range state.auditLog {
+		if key != "" && r.Change.GetKey() != key {
+			continue
+		}
+		if r.Timestamp < from || (to != 0 && r.Timestamp > to) {
+			continue
+		}
+		records = append(records, proto.Clone(r).(*pb_core_extensions.TuningAuditRecord))
+	}
+	return records
+}
+
+// Returns the value of a tuning parameter as it is logged, or "-" if there is no parameter
+func formatParameterValue(param *pb_systemmanager_messages.TuningState_Parameter) string {
+	switch {
+	case param.GetInt() != nil:
+		return strconv.FormatInt(param.GetInt().Value, 10)
+	case param.GetFloat() != nil:
+		return strconv.FormatFloat(float64(param.GetFloat().Value), 'g', -1, 32)
+	case param.GetString_() != nil:
+		return strconv.Quote(param.GetString_().Value)
+	default:
+		return "-"
+	}
+}
@@ -0,0 +1,151 @@
+package state
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	pb_systemmanager_messages "github.com/VU-ASE/rovercom/packages/go/core"
+	"google.golang.org/protobuf/proto"
+)
+
+// Returned when a tuning state contains parameters that violate the constraints of their service options.
+// It lists every violating parameter, not just the first one
+type TuningValidationError struct {
+	Violations []*pb_core_extensions.TuningViolation
+}
+
+func (e *TuningValidationError) Error() string {
+	reasons := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		reasons = append(reasons, fmt.Sprintf("%s %s", v.Key, v.Reason))
+	}
+	return fmt.Sprintf("Tuning state was rejected, %d parameter(s) are invalid: %s", len(e.Violations), strings.Join(reasons, "; "))
+}
+
+// Checks whether the constraints make sense for the options of the service that declares them
+func (state *State) ValidateOptionConstraints(service *pb_systemmanager_messages.Service, constraints []*pb_core_extensions.ServiceOptionConstraint) error {
+	for _, c := range constraints {
+		if c == nil {
+			continue
+		}
+
+		i := slices.IndexFunc(service.GetOptions(), func(o *pb_systemmanager_messages.ServiceOption) bool { return o != nil && o.Name == c.Option })
+		if i < 0 {
+			return fmt.Errorf("Constraint for option '%s' does not belong to any option of this service", c.Option)
+		}
+		option := service.Options[i]
+
+		isNumeric := c.Min != nil || c.Max != nil || c.Step != nil
+		if isNumeric && option.Type == pb_systemmanager_messages.ServiceOption_STRING {
+			return fmt.Errorf("Constraint for option '%s' has a min, max or step, but the option is a string", c.Option)
+		}
+		if len(c.Allowed) > 0 && option.Type != pb_systemmanager_messages.ServiceOption_STRING {
+			return fmt.Errorf("Constraint for option '%s' has allowed values, but the option is not a string", c.Option)
+		}
+		if c.Min != nil && c.Max != nil && c.GetMin() > c.GetMax() {
+			return fmt.Errorf("Constraint for option '%s' has a min (%v) that is larger than its max (%v)", c.Option, c.GetMin(), c.GetMax())
+		}
+		if c.Step != nil && c.GetStep() <= 0 {
+			return fmt.Errorf("Constraint for option '%s' has a step (%v) that is not positive", c.Option, c.GetStep())
+		}
+	}
+	return nil
+}
+
+// Replaces the constraints of all options of the service with the given ones
+func (state *State) setOptionConstraints(service *pb_systemmanager_messages.Service, constraints []*pb_core_extensions.ServiceOptionConstraint) {
+	if state.optionConstraints == nil {
+		state.optionConstraints = make(map[string]*pb_core_extensions.ServiceOptionConstraint)
+	}
+	for _, o := range service.GetOptions() {
+		delete(state.optionConstraints, o.Name)
+	}
+	for _, c := range constraints {
+		if c != nil {
+			state.optionConstraints[c.Option] = proto.Clone(c).(*pb_core_extensions.ServiceOptionConstraint)
+		}
+	}
+}
+
+// Returns the constraint for the option with the given key, as long as the service that declared it is still around
+func (state *State) GetOptionConstraint(key string) *pb_core_extensions.ServiceOptionConstraint {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	constraint := state.optionConstraint(key)
+	if constraint == nil {
+		return nil
+	}
+	return proto.Clone(constraint).(*pb_core_extensions.ServiceOptionConstraint)
+}
+
+func (state *State) optionConstraint(key string) *pb_core_extensions.ServiceOptionConstraint {
+	if option, _ := state.getServiceOption(key); option == nil {
+		return nil
+	}
+	return state.optionConstraints[key]
+}
+
+// Checks all parameters of the tuning state against the constraints of their options
+func (state *State) validateTuningState(ts *pb_systemmanager_messages.TuningState) error {
+	violations := make([]*pb_core_extensions.TuningViolation, 0)
+	for _, p := range ts.DynamicParameters {
+		key, _ := getKeyAndType(p)
+		reason := checkParameter(p, state.optionConstraint(key))
+		if reason != "" {
+			violations = append(violations, &pb_core_extensions.TuningViolation{
+				Key:    key,
+				Reason: reason,
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return &TuningValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// Returns why the parameter violates the constraint, or an empty string if it does not
+func checkParameter(param *pb_systemmanager_messages.TuningState_Parameter, constraint *pb_core_extensions.ServiceOptionConstraint) string {
+	var value float64
+	switch {
+	case param.GetFloat() != nil:
+		value = float64(param.GetFloat().Value)
+		// There is no option that can do anything sensible with these
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return "is not a finite number"
+		}
+	case param.GetInt() != nil:
+		value = float64(param.GetInt().Value)
+	case param.GetString_() != nil:
+		if constraint != nil && len(constraint.Allowed) > 0 && !slices.Contains(constraint.Allowed, param.GetString_().Value) {
+			return fmt.Sprintf("must be one of [%s]", strings.Join(constraint.Allowed, ", "))
+		}
+		return ""
+	default:
+		return ""
+	}
+
+	if constraint == nil {
+		return ""
+	}
+	if constraint.Min != nil && value < constraint.GetMin() {
+		return fmt.Sprintf("must be at least %v", constraint.GetMin())
+	}
+	if constraint.Max != nil && value > constraint.GetMax() {
+		return fmt.Sprintf("must be at most %v", constraint.GetMax())
+	}
+	if constraint.Step != nil {
+		// Float parameters are only float32, so allow for some rounding
+		steps := (value - constraint.GetMin()) / constraint.GetStep()
+		if math.Abs(steps-math.Round(steps)) > 1e-4 {
+			return fmt.Sprintf("must be a multiple of %v from %v", constraint.GetStep(), constraint.GetMin())
+		}
+	}
+	return ""
+}
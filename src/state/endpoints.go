@@ -0,0 +1,73 @@
+package state
+
+import (
+	"slices"
+	"strings"
+	"vu/ase/core/src/services"
+
+	pb_systemmanager_messages "github.com/VU-ASE/rovercom/packages/go/core"
+	"github.com/rs/zerolog/log"
+)
+
+// An endpoint of a registered service, in the form that the service binds to and the form that clients can connect to.
+// The services in the state only hold the connect form
+type Endpoint struct {
+	Service        string
+	Pid            int32
+	Name           string
+	BindAddress    string
+	ConnectAddress string
+}
+
+// Replaces the (bind) addresses of the endpoints of the service by addresses that clients can connect to, and remembers both forms
+func (state *State) resolveEndpoints(service *pb_systemmanager_messages.Service) {
+	for _, e := range service.Endpoints {
+		if e == nil {
+			continue
+		}
+
+		connectAddress, err := services.ConnectableAddress(e.Address, state.AdvertisedHost)
+		if err != nil {
+			log.Warn().Err(err).Str("service", service.Identifier.Name).Str("endpoint", e.Name).Msg("Could not resolve endpoint address, keeping it as it is")
+			connectAddress = e.Address
+		}
+		if connectAddress != e.Address {
+			log.Debug().Str("service", service.Identifier.Name).Str("endpoint", e.Name).Str("bind", e.Address).Str("connect", connectAddress).Msg("Resolved endpoint address")
+		}
+
+		state.endpoints = append(state.endpoints, &Endpoint{
+			Service:        service.Identifier.Name,
+			Pid:            service.Identifier.Pid,
+			Name:           e.Name,
+			BindAddress:    e.Address,
+			ConnectAddress: connectAddress,
+		})
+		e.Address = connectAddress
+	}
+}
+
+// Returns both forms of all endpoints of the service with exactly this name and pid
+func (state *State) GetEndpoints(name string, pid int32) []*Endpoint {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	return state.getEndpoints(name, pid)
+}
+
+func (state *State) getEndpoints(name string, pid int32) []*Endpoint {
+	endpoints := make([]*Endpoint, 0)
+	for _, e := range state.endpoints {
+		if strings.EqualFold(e.Service, name) && e.Pid == pid {
+			copied := *e
+			endpoints = append(endpoints, &copied)
+		}
+	}
+	return endpoints
+}
+
+// Removes the endpoints of services that are no longer registered
+func (state *State) pruneEndpoints() {
+	state.endpoints = slices.DeleteFunc(state.endpoints, func(endpoint *Endpoint) bool {
+		return !state.isRegistered(endpoint.Service, endpoint.Pid)
+	})
+}
@@ -0,0 +1,29 @@
+package state
+
+import (
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	pb_systemmanager_messages "github.com/VU-ASE/rovercom/packages/go/core"
+)
+
+// Passes a status change or removal of a service to OnServiceEvent (if set). A removed service is passed with status STOPPED
+// (and without an extended status)
+func (state *State) emitServiceEvent(service *pb_systemmanager_messages.Service, previous pb_systemmanager_messages.ServiceStatus, removed bool, reason pb_core_extensions.ServiceEvent_Reason) {
+	if state.OnServiceEvent == nil {
+		return
+	}
+
+	changed := cloneService(service)
+	extendedStatus := state.extendedStatus(service)
+	if removed {
+		changed.Status = pb_systemmanager_messages.ServiceStatus_STOPPED
+		extendedStatus = pb_core_extensions.ExtendedServiceStatus_NO_EXTENDED_STATUS
+	}
+	state.OnServiceEvent(&pb_core_extensions.ServiceEvent{
+		Service:        changed,
+		PreviousStatus: previous,
+		Removed:        removed,
+		Reason:         reason,
+		ExtendedStatus: extendedStatus,
+	})
+}
@@ -0,0 +1,183 @@
+package state
+
+import (
+	"fmt"
+	"slices"
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	pb_systemmanager_messages "github.com/VU-ASE/rovercom/packages/go/core"
+	"google.golang.org/protobuf/proto"
+)
+
+// The number of tuning revisions that are remembered, older revisions are forgotten
+const MaxTuningRevisions = 100
+
+// Returned when a tuning state update was based on a revision that is no longer the latest one
+type TuningConflictError struct {
+	BaseRevision uint64
+	// The latest revision, so that the client can rebase its changes onto it
+	Current *pb_core_extensions.TuningRevision
+}
+
+func (e *TuningConflictError) Error() string {
+	return fmt.Sprintf("Tuning state was changed since revision %d (it is now at revision %d), rebase your changes onto the current tuning state and try again", e.BaseRevision, e.Current.GetRevision())
+}
+
+// Adds a revision to the tuning history for the change from the previous to the new tuning state
+func (state *State) recordTuningRevision(previous *pb_systemmanager_messages.TuningState, ts *pb_systemmanager_messages.TuningState) *pb_core_extensions.TuningRevision {
+	changedKeys := make([]string, 0)
+	for _, c := range DiffTuningStates(previous, ts) {
+		changedKeys = append(changedKeys, c.Key)
+	}
+
+	revision := &pb_core_extensions.TuningRevision{
+		Revision:    state.tuningRevisionNumber() + 1,
+		Timestamp:   ts.Timestamp,
+		ChangedKeys: changedKeys,
+		State:       proto.Clone(ts).(*pb_systemmanager_messages.TuningState),
+	}
+	state.tuningHistory = append(state.tuningHistory, revision)
+	if len(state.tuningHistory) > MaxTuningRevisions {
+		state.tuningHistory = slices.Delete(state.tuningHistory, 0, len(state.tuningHistory)-MaxTuningRevisions)
+	}
+	return revision
+}
+
+// Returns the number of the latest tuning revision, or 0 if the tuning state was never changed
+func (state *State) GetTuningRevisionNumber() uint64 {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	return state.tuningRevisionNumber()
+}
+
+func (state *State) tuningRevisionNumber() uint64 {
+	if len(state.tuningHistory) == 0 {
+		return 0
+	}
+	return state.tuningHistory[len(state.tuningHistory)-1].Revision
+}
+
+// Returns the latest tuning revision, with the tuning state as services see it (i.e. combined with their defaults)
+func (state *State) GetCurrentTuningRevision() *pb_core_extensions.TuningRevision {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	return state.currentTuningRevision()
+}
+
+func (state *State) currentTuningRevision() *pb_core_extensions.TuningRevision {
+	current := &pb_core_extensions.TuningRevision{
+		Revision: state.tuningRevisionNumber(),
+		State:    state.getTuningState(),
+	}
+	if len(state.tuningHistory) > 0 {
+		latest := state.tuningHistory[len(state.tuningHistory)-1]
+		current.Timestamp = latest.Timestamp
+		current.ChangedKeys = slices.Clone(latest.ChangedKeys)
+	}
+	return current
+}
+
+// Fails with a *TuningConflictError if the tuning state has changed since the given revision
+func (state *State) checkTuningRevision(base uint64) error {
+	if state.tuningRevisionNumber() == base {
+		return nil
+	}
+	return &TuningConflictError{
+		BaseRevision: base,
+		Current:      state.currentTuningRevision(),
+	}
+}
+
+// Returns all remembered tuning revisions (oldest first), without their tuning states
+func (state *State) GetTuningRevisions() []*pb_core_extensions.TuningRevision {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	revisions := make([]*pb_core_extensions.TuningRevision, 0, len(state.tuningHistory))
+	for _, r := range state.tuningHistory {
+		revisions = append(revisions, &pb_core_extensions.TuningRevision{
+			Revision:    r.Revision,
+			Timestamp:   r.Timestamp,
+			ChangedKeys: slices.Clone(r.ChangedKeys),
+		})
+	}
+	return revisions
+}
+
+// Returns the tuning revision with the given number, or nil if it is not (or no longer) in the history
+func (state *State) GetTuningRevision(revision uint64) *pb_core_extensions.TuningRevision {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	r := state.getTuningRevision(revision)
+	if r == nil {
+		return nil
+	}
+	return proto.Clone(r).(*pb_core_extensions.TuningRevision)
+}
+
+func (state *State) getTuningRevision(revision uint64) *pb_core_extensions.TuningRevision {
+	for _, r := range state.tuningHistory {
+		if r.Revision == revision {
+			return r
+		}
+	}
+	return nil
+}
+
+// Returns the changes between two tuning revisions. Revision 0 is the (empty) tuning state before the first change
+func (state *State) DiffTuningRevisions(from uint64, to uint64) ([]*pb_core_extensions.TuningParameterChange, error) {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	states := make([]*pb_systemmanager_messages.TuningState, 0, 2)
+	for _, revision := range []uint64{from, to} {
+		if revision == 0 {
+			states = append(states, &pb_systemmanager_messages.TuningState{})
+			continue
+		}
+		r := state.getTuningRevision(revision)
+		if r == nil {
+			return nil, fmt.Errorf("Tuning revision %d does not exist (anymore)", revision)
+		}
+		states = append(states, r.State)
+	}
+
+	// The changes point into the stored revisions
+	changes := DiffTuningStates(states[0], states[1])
+	for i, c := range changes {
+		changes[i] = proto.Clone(c).(*pb_core_extensions.TuningParameterChange)
+	}
+	return changes, nil
+}
+
+// Compares two tuning states and returns a change for every parameter that was added, removed or changed (in value or type)
+func DiffTuningStates(from *pb_systemmanager_messages.TuningState, to *pb_systemmanager_messages.TuningState) []*pb_core_extensions.TuningParameterChange {
+	changes := make([]*pb_core_extensions.TuningParameterChange, 0)
+
+	for _, np := range to.GetDynamicParameters() {
+		key, _ := getKeyAndType(np)
+		op := findParameter(key, from.GetDynamicParameters())
+		if op == nil || !proto.Equal(op, np) {
+			changes = append(changes, &pb_core_extensions.TuningParameterChange{
+				Key: key,
+				Old: op,
+				New: np,
+			})
+		}
+	}
+
+	for _, op := range from.GetDynamicParameters() {
+		key, _ := getKeyAndType(op)
+		if findParameter(key, to.GetDynamicParameters()) == nil {
+			changes = append(changes, &pb_core_extensions.TuningParameterChange{
+				Key: key,
+				Old: op,
+			})
+		}
+	}
+
+	return changes
+}
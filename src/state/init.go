@@ -4,26 +4,85 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"sync"
 	"time"
-	"vu/ase/core/src/procutils"
+	pb_core_extensions "vu/ase/core/src/extensions"
 	"vu/ase/core/src/services"
 
 	pb_systemmanager_messages "github.com/VU-ASE/rovercom/packages/go/core"
 	zmq "github.com/pebbe/zmq4"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
 )
 
 // A list of all registered services
 type ServiceList []*pb_systemmanager_messages.Service
 
+// The registry of everything core knows about. It is shared between the request handlers and the background workers (status checks,
+// lease checks, the supervisor), so its contents are only accessible through its methods. These take the lock themselves, and never
+// hand out the objects that are stored in the state: what they return is a copy that the caller is free to modify
 type State struct {
-	Services        ServiceList
+	// Guards all fields below. The unexported methods expect the caller to hold it
+	lock        sync.RWMutex
+	services    ServiceList
+	tuningState *pb_systemmanager_messages.TuningState
+	// The processes that core started, these are the only processes that core will stop when it terminates
+	managedProcesses []*ManagedProcess
+	// The most recent changes to the tuning state, oldest first
+	tuningHistory []*pb_core_extensions.TuningRevision
+	// The constraints that services declared for their options, by option name
+	optionConstraints map[string]*pb_core_extensions.ServiceOptionConstraint
+	// The sessions of the services that registered with one
+	sessions []*Session
+	// The leases of the services that registered with one
+	leases []*Lease
+	// The instance IDs of the services that registered with one
+	instances []*Instance
+	// Both forms of the endpoint addresses of all services
+	endpoints []*Endpoint
+	// The ports that core assigned to the outputs of services
+	ports []*PortAllocation
+	// One record for every change to a tuning parameter, oldest first
+	auditLog []*pb_core_extensions.TuningAuditRecord
+
+	// These are set once, before the state is shared
 	PublisherSocket *zmq.Socket
-	TuningState     *pb_systemmanager_messages.TuningState
+	// If set, the tuning state is saved to this file after every update
+	TuningStatePath string
+	// If set, the audit records of every update to the tuning state are appended to this file
+	AuditLogPath string
+	// If set, wildcard hosts in endpoint addresses are replaced by this host, instead of the address of one of the interfaces
+	AdvertisedHost string
+	// The ports that can be assigned to the outputs of services, if any
+	PortRange PortRange
+	// Called for every status change and removal of a registered service. It is called while the state is locked,
+	// so it must not use the state itself
+	OnServiceEvent func(event *pb_core_extensions.ServiceEvent)
+}
+
+func NewState(publisherSocket *zmq.Socket, tuningStatePath string, advertisedHost string, portRange PortRange) *State {
+	return &State{
+		services: make(ServiceList, 0),
+		tuningState: &pb_systemmanager_messages.TuningState{
+			Timestamp:         0,
+			DynamicParameters: []*pb_systemmanager_messages.TuningState_Parameter{},
+		},
+		PublisherSocket: publisherSocket,
+		TuningStatePath: tuningStatePath,
+		AdvertisedHost:  advertisedHost,
+		PortRange:       portRange,
+	}
 }
 
 func (state *State) GetService(name string) *pb_systemmanager_messages.Service {
-	for _, s := range state.Services {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	return cloneService(state.getService(name))
+}
+
+func (state *State) getService(name string) *pb_systemmanager_messages.Service {
+	for _, s := range state.services {
 		if s != nil && strings.EqualFold(s.Identifier.Name, name) {
 			return s
 		}
@@ -31,44 +90,223 @@ func (state *State) GetService(name string) *pb_systemmanager_messages.Service {
 	return nil
 }
 
+// Returns a copy of the list of all registered services
+func (state *State) GetServices() ServiceList {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	if state.services == nil {
+		return nil
+	}
+	services := make(ServiceList, 0, len(state.services))
+	for _, s := range state.services {
+		services = append(services, cloneService(s))
+	}
+	return services
+}
+
+// Checks whether the service with exactly this name and pid is in the list of services
+func (state *State) isRegistered(name string, pid int32) bool {
+	return slices.ContainsFunc(state.services, func(s *pb_systemmanager_messages.Service) bool {
+		return s != nil && strings.EqualFold(s.Identifier.Name, name) && s.Identifier.Pid == pid
+	})
+}
+
 func (state *State) AddService(service *pb_systemmanager_messages.Service) {
-	if service != nil {
-		log.Info().Str("name", service.Identifier.Name).Int32("pid", service.Identifier.Pid).Msg("Added service")
-		state.Services = append(state.Services, service)
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	state.addService(service)
+}
+
+// Adds a copy of the service, in which the endpoint addresses are the ones that clients can connect to
+func (state *State) addService(service *pb_systemmanager_messages.Service) *pb_systemmanager_messages.Service {
+	if service == nil {
+		return nil
 	}
+	log.Info().Str("name", service.Identifier.Name).Int32("pid", service.Identifier.Pid).Msg("Added service")
+	added := cloneService(service)
+	state.resolveEndpoints(added)
+	state.services = append(state.services, added)
+	return cloneService(added)
+}
+
+// What a service can ask for when it registers, besides being added to the list of services
+type Registration struct {
+	// Optional, several instances of the same service can be registered if each of them has a different one
+	Instance string
+	// For every one of these outputs, an endpoint is added with a port from the port range
+	AllocateOutputs []string
+	// The constraints of the options of the service, see ValidateOptionConstraints
+	Constraints []*pb_core_extensions.ServiceOptionConstraint
+	// If set, the service gets a session, after which only the holder of its token can change the service
+	WithSession bool
+	// If set, the service gets a lease of this duration (clamped to the allowed range), which it must keep renewing
+	LeaseDuration time.Duration
+}
+
+// A service as it was registered, with the session and lease it asked for (if any)
+type RegisteredService struct {
+	Service *pb_systemmanager_messages.Service
+	Session *Session
+	Lease   *Lease
+}
+
+// Adds the service to the list of services, if it can be registered at all. All checks and the registration itself (including its
+// session, constraints and lease) happen at once, so that two services cannot both pass the checks before either of them is added,
+// and nobody can see the service before it is completely registered. Returns copies of what was registered
+func (state *State) RegisterService(service *pb_systemmanager_messages.Service, registration Registration) (*RegisteredService, error) {
+	err := state.ValidateOptionConstraints(service, registration.Constraints)
+	if err != nil {
+		return nil, fmt.Errorf("Tried to register service '%s' but failed: %v", service.Identifier.Name, err)
+	}
+	var session *Session
+	if registration.WithSession {
+		session, err = newSession(service)
+		if err != nil {
+			return nil, fmt.Errorf("Tried to register service '%s' but failed: could not create a session: %v", service.Identifier.Name, err)
+		}
+	}
+	instance := registration.Instance
+
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	// We can't register a service that is already registered (by name and instance)
+	for _, s := range slices.Clone(state.services) {
+		if s == nil || !strings.EqualFold(s.Identifier.Name, service.Identifier.Name) {
+			continue
+		}
+		// A process can only be one instance of a service
+		if !instancesConflict(instance, state.instanceID(s)) && s.Identifier.Pid != service.Identifier.Pid {
+			continue
+		}
+		if state.serviceIsAlive(s) {
+			log.Warn().Str("service", s.Identifier.Name).Str("instance", state.instanceID(s)).Int("pid", int(s.Identifier.Pid)).Msg("Attempted to register service that was already registered and is still active")
+			if s.Identifier.Pid == service.Identifier.Pid {
+				return nil, fmt.Errorf("Tried to register service '%s' but failed: this process (PID %d) is already registered as an instance of this service", service.Identifier.Name, s.Identifier.Pid)
+			}
+			if instance != "" && state.instanceID(s) == instance {
+				return nil, fmt.Errorf("Tried to register service '%s' but failed: instance '%s' of this service is already registered and still running (running with PID %d) ", service.Identifier.Name, instance, s.Identifier.Pid)
+			}
+			return nil, fmt.Errorf("Tried to register service '%s' but failed: this service is already registered and still running (running with PID %d). To run multiple instances of a service, every instance needs its own instance ID ", service.Identifier.Name, s.Identifier.Pid)
+		}
+		// An unresponsive service that is replaced by a new registration will not come back
+		state.removeService(s.Identifier.Name, s.Identifier.Pid, pb_core_extensions.ServiceEvent_REPLACED)
+	}
+
+	// We can't register a service with tuning options that are already used by another service
+	// (instances of the same service share their options, and thereby their tuning parameters)
+	for _, o := range service.Options {
+		existingOption, existingService := state.getServiceOption(o.Name)
+		if existingOption != nil && existingService != nil && !strings.EqualFold(existingService.Identifier.Name, service.Identifier.Name) {
+			return nil, fmt.Errorf("Tried to register service '%s' but failed: the service option %s is already in use by service '%s'. Change the name of this option in the service.yaml of service '%s' or stop service '%s' (running with PID %d) ", service.Identifier.Name, existingOption.Name, existingService.Identifier.Name, service.Identifier.Name, existingService.Identifier.Name, existingService.Identifier.Pid)
+		}
+	}
+
+	// We can't register a service that would introduce a dependency cycle with the services that are already registered
+	_, err = services.DependencyGraphFromServices(append(slices.Clone(state.services), service)).TopologicalOrder()
+	if err != nil {
+		log.Warn().Err(err).Str("service", service.Identifier.Name).Msg("Attempted to register service with cyclic dependencies")
+		return nil, fmt.Errorf("Tried to register service '%s' but failed: %v", service.Identifier.Name, err)
+	}
+
+	registered := cloneService(service)
+	err = state.allocateOutputs(registered, registration.AllocateOutputs)
+	if err != nil {
+		return nil, fmt.Errorf("Tried to register service '%s' but failed: %v", service.Identifier.Name, err)
+	}
+	registered.Status = pb_systemmanager_messages.ServiceStatus_REGISTERED
+	// The registration timestamp is necessary to fetch tuning states later
+	registered.RegisteredAt = time.Now().UnixMilli()
+	registered = state.addService(registered)
+	if instance != "" {
+		state.instances = append(state.instances, &Instance{
+			Name: registered.Identifier.Name,
+			Pid:  registered.Identifier.Pid,
+			ID:   instance,
+		})
+	}
+	state.setOptionConstraints(registered, registration.Constraints)
+
+	result := &RegisteredService{
+		Service: registered,
+	}
+	if session != nil {
+		state.addSession(session)
+		copied := *session
+		result.Session = &copied
+	}
+	// Without a lease, the service is alive for as long as its pid exists
+	if registration.LeaseDuration > 0 {
+		result.Lease = state.grantLease(registered, registration.LeaseDuration)
+	}
+	return result, nil
 }
 
 func (state *State) UpdateServiceStatus(name string, pid int32, status pb_systemmanager_messages.ServiceStatus) (*pb_systemmanager_messages.Service, error) {
-	for _, s := range state.Services {
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	for _, s := range state.services {
 		if s != nil && strings.EqualFold(s.Identifier.Name, name) && s.Identifier.Pid == pid {
+			previous := s.Status
 			s.Status = status
-			return s, nil
+			if previous != status {
+				state.emitServiceEvent(s, previous, false, pb_core_extensions.ServiceEvent_REPORTED)
+			}
+			return cloneService(s), nil
 		}
 	}
 
 	return nil, fmt.Errorf("Could not find service '%s' and pid '%d' to update status for", name, pid)
 }
 
-func (state *State) RemoveService(name string, pid int32) {
-	state.Services = slices.DeleteFunc(
-		state.Services,
+// Removes a service from the list of services, the reason is broadcast along with the removal
+func (state *State) RemoveService(name string, pid int32, reason pb_core_extensions.ServiceEvent_Reason) {
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	state.removeService(name, pid, reason)
+}
+
+func (state *State) removeService(name string, pid int32, reason pb_core_extensions.ServiceEvent_Reason) {
+	state.services = slices.DeleteFunc(
+		state.services,
 		func(s *pb_systemmanager_messages.Service) bool {
 			if s == nil {
 				return true
 			}
 			removed := strings.EqualFold(s.Identifier.Name, name) && s.Identifier.Pid == pid
 			if removed {
-				log.Info().Str("name", name).Int32("pid", pid).Msg("Removed service")
+				log.Info().Str("name", name).Int32("pid", pid).Str("reason", reason.String()).Msg("Removed service")
+				state.emitServiceEvent(s, s.Status, true, reason)
 			}
 			return removed
 		},
 	)
+	state.pruneSessions()
+	state.pruneLeases()
+	state.pruneInstances()
+	state.pruneEndpoints()
+	state.prunePorts()
 }
 
 // Iterates over all services and checks if they have a tuning option with the given key and returns the first one found (there should be 0 or 1, but not more)
 func (state *State) GetServiceOption(key string) (*pb_systemmanager_messages.ServiceOption, *pb_systemmanager_messages.Service) {
-	for _, s := range state.Services {
-		if s != nil && s.Options != nil && procutils.ProcessExists(int(s.Identifier.Pid)) {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	option, service := state.getServiceOption(key)
+	if option == nil {
+		return nil, nil
+	}
+	return proto.Clone(option).(*pb_systemmanager_messages.ServiceOption), cloneService(service)
+}
+
+func (state *State) getServiceOption(key string) (*pb_systemmanager_messages.ServiceOption, *pb_systemmanager_messages.Service) {
+	for _, s := range state.services {
+		if s != nil && s.Options != nil && state.serviceIsAlive(s) {
 			for _, o := range s.Options {
 				if o != nil && o.Name == key {
 					return o, s
@@ -81,37 +319,117 @@ func (state *State) GetServiceOption(key string) (*pb_systemmanager_messages.Ser
 }
 
 // This function will go over the entire list of services and update their status according to the current state of the system (using systemctl).
+// Services with a lease are left alone, their status is managed by CheckLeases
 func (state *State) UpdateServiceStatusses() {
-	for _, s := range state.Services {
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	previous := make(map[*pb_systemmanager_messages.Service]pb_systemmanager_messages.ServiceStatus)
+	for _, s := range state.services {
 		if s != nil {
-			s.Status = services.ServiceStatus(s)
+			previous[s] = s.Status
+			s.Status = state.serviceStatus(s)
 		}
 	}
 	// delete all stopped services
-	state.Services = slices.DeleteFunc(
-		state.Services,
+	state.services = slices.DeleteFunc(
+		state.services,
 		func(s *pb_systemmanager_messages.Service) bool {
 			delete := s.Status == pb_systemmanager_messages.ServiceStatus_STOPPED || s.Status == pb_systemmanager_messages.ServiceStatus_NOT_REGISTERED || s.Status == pb_systemmanager_messages.ServiceStatus_UNKNOWN
+			// A service with an expired lease is unresponsive rather than gone, CheckLeases removes it if it does not come back.
+			// A crash-looping service is kept (as stopped) until it is ordered to restart or stop, so that it can be queried
+			if lease := state.getLease(s.Identifier.Name, s.Identifier.Pid); lease != nil && lease.Expired {
+				delete = false
+			} else if state.isCrashLooping(s.Identifier.Name, s.Identifier.Pid) {
+				delete = false
+			}
 			if delete {
 				log.Info().Str("name", s.Identifier.Name).Int32("pid", s.Identifier.Pid).Msg("Removed service")
+				state.emitServiceEvent(s, previous[s], true, pb_core_extensions.ServiceEvent_PROCESS_GONE)
 			}
 			return delete
 		},
 	)
+	state.pruneSessions()
+	state.pruneLeases()
+	state.pruneInstances()
+	state.pruneEndpoints()
+	state.prunePorts()
+}
+
+// Replaces the tuning state without any checks, used to restore the tuning revision of a previous run. The revision becomes the
+// only one in the history, so that the revision numbers of later changes continue from it
+func (state *State) RestoreTuningState(revision *pb_core_extensions.TuningRevision) {
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	state.tuningState = proto.Clone(revision.State).(*pb_systemmanager_messages.TuningState)
+	state.tuningHistory = nil
+	if revision.Revision > 0 {
+		state.tuningHistory = append(state.tuningHistory, proto.Clone(revision).(*pb_core_extensions.TuningRevision))
+	}
+}
+
+// A change to the tuning state, see ApplyTuningUpdate
+type TuningUpdate struct {
+	State *pb_systemmanager_messages.TuningState
+	// If set, the parameters of State are added to (or overwrite those in) the current tuning state, and the parameters with
+	// one of the DeleteKeys are removed. Otherwise, State replaces the current tuning state entirely
+	Merge      bool
+	DeleteKeys []string
+	// If set, the update is rejected with a *TuningConflictError when the tuning state was changed since this revision
+	BaseRevision *uint64
+	// The identity of the client that asked for the update, as recorded in the audit log. Empty if it is unknown
+	Client string
 }
 
 // This will replace the current tuning state with a new one, and return the new tuning state
-// it will *not* merge the tuning state with the old one, but replace it entirely
-func (state *State) UpdateTuningState(ts *pb_systemmanager_messages.TuningState) *pb_systemmanager_messages.TuningState {
+// it will *not* merge the tuning state with the old one, but replace it entirely (see ApplyTuningUpdate for partial updates)
+// If any of the parameters violates the constraints of its option, nothing is changed and a *TuningValidationError is returned
+func (state *State) UpdateTuningState(ts *pb_systemmanager_messages.TuningState) (*pb_systemmanager_messages.TuningState, error) {
+	revision, err := state.ApplyTuningUpdate(TuningUpdate{State: ts})
+	if err != nil {
+		return nil, err
+	}
+	return revision.State, nil
+}
+
+// Applies a change to the tuning state and returns the resulting revision. The revision check, the merge and the update happen at once,
+// so that concurrent updates cannot overwrite each other's changes unnoticed
+func (state *State) ApplyTuningUpdate(update TuningUpdate) (*pb_core_extensions.TuningRevision, error) {
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
 	log.Info().Msg("Updating tuning state")
 
+	// Don't let a client overwrite changes that it has not seen yet
+	if update.BaseRevision != nil {
+		err := state.checkTuningRevision(*update.BaseRevision)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// A merge starts from the tuning state as services see it, in which the defaults of services that registered after the
+	// last change take precedence, so that the parameters it does not name keep the values they have now
+	previous := state.tuningState
+	var ts *pb_systemmanager_messages.TuningState
+	if update.Merge {
+		previous = state.getTuningState()
+		ts = mergeTuningStates(previous, update.State, update.DeleteKeys)
+	} else if update.State != nil {
+		ts = proto.Clone(update.State).(*pb_systemmanager_messages.TuningState)
+	} else {
+		ts = &pb_systemmanager_messages.TuningState{}
+	}
+
 	// Set the timestampp, so that it can be compared to local options later
 	ts.Timestamp = uint64(time.Now().UnixMilli())
 
 	// Delete the parameters that are not valid
 	// - because they have a type that does not match the type of a service option
 	ts.DynamicParameters = slices.DeleteFunc(ts.DynamicParameters, func(p *pb_systemmanager_messages.TuningState_Parameter) bool {
-		for _, s := range state.Services {
+		for _, s := range state.services {
 			for _, o := range s.Options {
 				if optionMismatchesParameter(o, p) {
 					log.Debug().Msgf("Deleting parameter %s because it does not match any service option", o.Name)
@@ -123,14 +441,70 @@ func (state *State) UpdateTuningState(ts *pb_systemmanager_messages.TuningState)
 		return false
 	})
 
-	state.TuningState = ts
-	return state.GetTuningState()
+	// Do not accept any of the parameters if one of them is invalid
+	err := state.validateTuningState(ts)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := state.recordTuningRevision(previous, ts)
+	state.recordTuningAudit(revision, previous, update.Client)
+	state.tuningState = ts
+
+	// Keep a copy on disk, so that the tuning state (and its revision number) survives a restart of core
+	if state.TuningStatePath != "" {
+		err := SaveTuningState(state.TuningStatePath, revision)
+		if err != nil {
+			log.Err(err).Str("path", state.TuningStatePath).Msg("Failed to save tuning state")
+		}
+	}
+
+	return state.currentTuningRevision(), nil
+}
+
+// Returns a copy of the current tuning state in which the parameters of ts are added or overwritten, and the parameters with
+// one of the deleteKeys are removed
+func mergeTuningStates(current *pb_systemmanager_messages.TuningState, ts *pb_systemmanager_messages.TuningState, deleteKeys []string) *pb_systemmanager_messages.TuningState {
+	merged := &pb_systemmanager_messages.TuningState{
+		DynamicParameters: make([]*pb_systemmanager_messages.TuningState_Parameter, 0),
+	}
+	if current != nil {
+		merged.DynamicParameters = append(merged.DynamicParameters, current.DynamicParameters...)
+	}
+
+	for _, p := range ts.GetDynamicParameters() {
+		key, _ := getKeyAndType(p)
+		i := slices.IndexFunc(merged.DynamicParameters, func(op *pb_systemmanager_messages.TuningState_Parameter) bool {
+			oldKey, _ := getKeyAndType(op)
+			return oldKey == key
+		})
+		if i >= 0 {
+			merged.DynamicParameters[i] = p
+		} else {
+			merged.DynamicParameters = append(merged.DynamicParameters, p)
+		}
+	}
+
+	merged.DynamicParameters = slices.DeleteFunc(merged.DynamicParameters, func(p *pb_systemmanager_messages.TuningState_Parameter) bool {
+		key, _ := getKeyAndType(p)
+		return slices.Contains(deleteKeys, key)
+	})
+
+	// The parameters are shared with the current tuning state and the update, which must not be changed
+	return proto.Clone(merged).(*pb_systemmanager_messages.TuningState)
 }
 
 // This will fetch the tuning state and compared it with the registered services.
 // If a service registered later than the latest tuning state, its service.yaml values take precedence.
 // Otherwise, the tuning state values take precedence, unless the service has declared a value as read-only (non-mutable)
 func (state *State) GetTuningState() *pb_systemmanager_messages.TuningState {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	return state.getTuningState()
+}
+
+func (state *State) getTuningState() *pb_systemmanager_messages.TuningState {
 	// We will not modify the saved tuning state, but create a new object with all combined values
 	combinedTuning := pb_systemmanager_messages.TuningState{
 		// This will be filled with the newly decided parameters
@@ -139,15 +513,20 @@ func (state *State) GetTuningState() *pb_systemmanager_messages.TuningState {
 	}
 
 	// Get the old tuning state and put the old parameters in an array so that we can eliminate nill checks
-	latest := state.TuningState
+	latest := state.tuningState
 	oldParams := make([]*pb_systemmanager_messages.TuningState_Parameter, 0)
 	if latest != nil {
 		oldParams = latest.DynamicParameters
 	}
 
 	// Go over all services and check if they have tuning options that are not in the tuning state, or if they registered later than the tuning state was last updated
-	for _, s := range state.Services {
+	for _, s := range state.services {
 		for _, o := range s.Options {
+			// Instances of the same service declare the same options, the first instance decides their defaults
+			if findParameter(o.Name, combinedTuning.DynamicParameters) != nil {
+				continue
+			}
+
 			// Try to find the original tuning parameter in the tuning state
 			existingParam := findParameter(o.Name, oldParams)
 			// Override/fill with the option value if:
@@ -184,5 +563,12 @@ func (state *State) GetTuningState() *pb_systemmanager_messages.TuningState {
 	}
 
 	log.Debug().Msgf("Returning updated tuning state %s (%d params)", combinedTuning.String(), len(combinedTuning.DynamicParameters))
-	return &combinedTuning
+	return proto.Clone(&combinedTuning).(*pb_systemmanager_messages.TuningState)
+}
+
+func cloneService(service *pb_systemmanager_messages.Service) *pb_systemmanager_messages.Service {
+	if service == nil {
+		return nil
+	}
+	return proto.Clone(service).(*pb_systemmanager_messages.Service)
 }
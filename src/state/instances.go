@@ -0,0 +1,79 @@
+package state
+
+import (
+	"slices"
+	"strings"
+
+	pb_systemmanager_messages "github.com/VU-ASE/rovercom/packages/go/core"
+)
+
+// Several processes can register under the same service name, as long as each of them has its own instance ID.
+// A service that registers without an instance ID is the only instance of that service
+type Instance struct {
+	Name string
+	Pid  int32
+	ID   string
+}
+
+// A registered service together with its instance ID (empty if it registered without one)
+type ServiceInstance struct {
+	Service   *pb_systemmanager_messages.Service
+	ID        string
+	Endpoints []*Endpoint
+}
+
+// Returns the service with exactly this name and pid, which tells instances of the same service apart
+func (state *State) GetServiceByIdentifier(name string, pid int32) *pb_systemmanager_messages.Service {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	return cloneService(state.getServiceByIdentifier(name, pid))
+}
+
+func (state *State) getServiceByIdentifier(name string, pid int32) *pb_systemmanager_messages.Service {
+	for _, s := range state.services {
+		if s != nil && strings.EqualFold(s.Identifier.Name, name) && s.Identifier.Pid == pid {
+			return s
+		}
+	}
+	return nil
+}
+
+// Returns all registered instances of the service with the given name, in the order in which they registered
+func (state *State) GetServiceInstances(name string) []*ServiceInstance {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	instances := make([]*ServiceInstance, 0)
+	for _, s := range state.services {
+		if s != nil && strings.EqualFold(s.Identifier.Name, name) {
+			instances = append(instances, &ServiceInstance{
+				Service:   cloneService(s),
+				ID:        state.instanceID(s),
+				Endpoints: state.getEndpoints(s.Identifier.Name, s.Identifier.Pid),
+			})
+		}
+	}
+	return instances
+}
+
+func (state *State) instanceID(service *pb_systemmanager_messages.Service) string {
+	for _, i := range state.instances {
+		if strings.EqualFold(i.Name, service.Identifier.Name) && i.Pid == service.Identifier.Pid {
+			return i.ID
+		}
+	}
+	return ""
+}
+
+// Two registrations of the same service conflict unless both have an instance ID and these differ
+func instancesConflict(id string, otherID string) bool {
+	return id == "" || otherID == "" || id == otherID
+}
+
+// Removes the instance IDs of services that are no longer registered
+func (state *State) pruneInstances() {
+	state.instances = slices.DeleteFunc(state.instances, func(instance *Instance) bool {
+		return !state.isRegistered(instance.Name, instance.Pid)
+	})
+}
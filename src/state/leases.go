@@ -0,0 +1,186 @@
+package state
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	pb_core_extensions "vu/ase/core/src/extensions"
+	"vu/ase/core/src/procutils"
+	"vu/ase/core/src/services"
+
+	pb_systemmanager_messages "github.com/VU-ASE/rovercom/packages/go/core"
+	"github.com/rs/zerolog/log"
+)
+
+// Leases are clamped to this range, shorter leases would need heartbeats faster than core checks them
+const MinLeaseDuration = 500 * time.Millisecond
+const MaxLeaseDuration = 10 * time.Minute
+
+// A service is removed from the registry once its lease has been expired for this many lease durations
+const LeaseRemovalFactor = 3
+
+// A service with a lease is considered alive for as long as it keeps renewing the lease, regardless of whether its pid exists
+// (which says nothing about a deadlocked service, or a service that runs on another machine)
+type Lease struct {
+	Name      string
+	Pid       int32
+	Duration  time.Duration
+	ExpiresAt time.Time
+	Expired   bool
+	// The status of the service before its lease expired, which it gets back when it renews the lease
+	StatusBeforeExpiry pb_systemmanager_messages.ServiceStatus
+}
+
+// Grants a lease to a registered service, replacing the one it had before (if any)
+func (state *State) grantLease(service *pb_systemmanager_messages.Service, duration time.Duration) *Lease {
+	lease := &Lease{
+		Name:      service.Identifier.Name,
+		Pid:       service.Identifier.Pid,
+		Duration:  min(max(duration, MinLeaseDuration), MaxLeaseDuration),
+		ExpiresAt: time.Now(),
+	}
+	lease.ExpiresAt = lease.ExpiresAt.Add(lease.Duration)
+
+	state.leases = slices.DeleteFunc(state.leases, func(l *Lease) bool {
+		return strings.EqualFold(l.Name, lease.Name) && l.Pid == lease.Pid
+	})
+	state.leases = append(state.leases, lease)
+	log.Info().Str("service", lease.Name).Int32("pid", lease.Pid).Str("duration", lease.Duration.String()).Msg("Granted lease")
+	copied := *lease
+	return &copied
+}
+
+func (state *State) GetLease(name string, pid int32) *Lease {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	lease := state.getLease(name, pid)
+	if lease == nil {
+		return nil
+	}
+	copied := *lease
+	return &copied
+}
+
+func (state *State) getLease(name string, pid int32) *Lease {
+	for _, l := range state.leases {
+		if l != nil && strings.EqualFold(l.Name, name) && l.Pid == pid {
+			return l
+		}
+	}
+	return nil
+}
+
+// Extends the lease of a service by its duration. If the lease had expired, the service gets its old status back
+func (state *State) RenewLease(name string, pid int32) (*Lease, error) {
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	lease := state.getLease(name, pid)
+	service := state.getServiceByIdentifier(name, pid)
+	if lease == nil || service == nil {
+		return nil, fmt.Errorf("Service '%s' (pid %d) has no lease to renew, it needs to register with a lease duration first", name, pid)
+	}
+
+	lease.ExpiresAt = time.Now().Add(lease.Duration)
+	renewed := *lease
+	if !lease.Expired {
+		return &renewed, nil
+	}
+
+	log.Info().Str("service", name).Int32("pid", pid).Msg("Service renewed its expired lease and is responsive again")
+	lease.Expired = false
+	renewed.Expired = false
+	service.Status = lease.StatusBeforeExpiry
+	state.emitServiceEvent(service, pb_systemmanager_messages.ServiceStatus_UNKNOWN, false, pb_core_extensions.ServiceEvent_LEASE_RENEWED)
+	return &renewed, nil
+}
+
+// Marks the services whose lease expired as unresponsive, and removes the services whose lease has been expired for too long
+func (state *State) CheckLeases(now time.Time) {
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	removed := make([]*pb_systemmanager_messages.Service, 0)
+	for _, lease := range state.leases {
+		service := state.getServiceByIdentifier(lease.Name, lease.Pid)
+		if service == nil {
+			continue
+		}
+
+		if now.After(lease.ExpiresAt.Add(lease.Duration * LeaseRemovalFactor)) {
+			log.Warn().Str("service", lease.Name).Int32("pid", lease.Pid).Msg("Service did not renew its lease for too long")
+			removed = append(removed, service)
+		} else if !lease.Expired && now.After(lease.ExpiresAt) {
+			log.Warn().Str("service", lease.Name).Int32("pid", lease.Pid).Msg("Service did not renew its lease in time and is now unresponsive")
+			lease.Expired = true
+			lease.StatusBeforeExpiry = service.Status
+			// Rovercom has no status for this, the extended status tells that the service is unresponsive
+			service.Status = pb_systemmanager_messages.ServiceStatus_UNKNOWN
+			state.emitServiceEvent(service, lease.StatusBeforeExpiry, false, pb_core_extensions.ServiceEvent_LEASE_EXPIRED)
+		}
+	}
+
+	for _, s := range removed {
+		state.removeService(s.Identifier.Name, s.Identifier.Pid, pb_core_extensions.ServiceEvent_LEASE_EXPIRED)
+	}
+}
+
+// Checks whether a registered service is still alive: by its lease if it has one, otherwise by its pid
+func (state *State) ServiceIsAlive(service *pb_systemmanager_messages.Service) bool {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	return state.serviceIsAlive(service)
+}
+
+func (state *State) serviceIsAlive(service *pb_systemmanager_messages.Service) bool {
+	if lease := state.getLease(service.Identifier.Name, service.Identifier.Pid); lease != nil {
+		return !lease.Expired
+	}
+	return procutils.ProcessExists(int(service.Identifier.Pid))
+}
+
+// The current status of a registered service. Services with a lease keep their status until CheckLeases changes it
+func (state *State) GetServiceStatus(service *pb_systemmanager_messages.Service) pb_systemmanager_messages.ServiceStatus {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	return state.serviceStatus(service)
+}
+
+func (state *State) serviceStatus(service *pb_systemmanager_messages.Service) pb_systemmanager_messages.ServiceStatus {
+	if service != nil && service.Identifier != nil && state.getLease(service.Identifier.Name, service.Identifier.Pid) != nil {
+		return service.Status
+	}
+	return services.ServiceStatus(service)
+}
+
+// Returns what core knows about a registered service on top of its status
+func (state *State) GetExtendedStatus(service *pb_systemmanager_messages.Service) pb_core_extensions.ExtendedServiceStatus {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	return state.extendedStatus(service)
+}
+
+func (state *State) extendedStatus(service *pb_systemmanager_messages.Service) pb_core_extensions.ExtendedServiceStatus {
+	if service == nil || service.Identifier == nil {
+		return pb_core_extensions.ExtendedServiceStatus_NO_EXTENDED_STATUS
+	}
+	if lease := state.getLease(service.Identifier.Name, service.Identifier.Pid); lease != nil && lease.Expired {
+		return pb_core_extensions.ExtendedServiceStatus_UNRESPONSIVE
+	}
+	if state.isCrashLooping(service.Identifier.Name, service.Identifier.Pid) {
+		return pb_core_extensions.ExtendedServiceStatus_CRASH_LOOPING
+	}
+	return pb_core_extensions.ExtendedServiceStatus_NO_EXTENDED_STATUS
+}
+
+// Removes the leases of services that are no longer registered
+func (state *State) pruneLeases() {
+	state.leases = slices.DeleteFunc(state.leases, func(lease *Lease) bool {
+		return !state.isRegistered(lease.Name, lease.Pid)
+	})
+}
@@ -0,0 +1,82 @@
+package state
+
+import (
+	"os"
+	"testing"
+	"time"
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	pb_systemmanager_messages "github.com/VU-ASE/rovercom/packages/go/core"
+)
+
+// Registers the service "imaging" with the shortest lease, and returns the service events that the state emits from then on
+func newLeasedState(t *testing.T) (*State, *[]*pb_core_extensions.ServiceEvent) {
+	state := NewState(nil, "", "", PortRange{})
+	events := make([]*pb_core_extensions.ServiceEvent, 0)
+	state.OnServiceEvent = func(event *pb_core_extensions.ServiceEvent) {
+		events = append(events, event)
+	}
+	_, err := state.RegisterService(&pb_systemmanager_messages.Service{
+		Identifier: &pb_systemmanager_messages.ServiceIdentifier{Name: "imaging", Pid: int32(os.Getpid())},
+	}, Registration{LeaseDuration: MinLeaseDuration})
+	if err != nil {
+		t.Fatalf("Could not register service: %v", err)
+	}
+	return state, &events
+}
+
+// An expired lease makes the service unresponsive, which it stays through status updates until it renews the lease
+func TestLeaseExpiryAndRenewal(t *testing.T) {
+	state, _ := newLeasedState(t)
+	pid := int32(os.Getpid())
+
+	state.CheckLeases(time.Now().Add(2 * MinLeaseDuration))
+	state.UpdateServiceStatusses()
+	service := state.GetService("imaging")
+	if service == nil {
+		t.Fatalf("Service with an expired lease was removed")
+	}
+	if extended := state.GetExtendedStatus(service); extended != pb_core_extensions.ExtendedServiceStatus_UNRESPONSIVE {
+		t.Errorf("Expected extended status UNRESPONSIVE after expiry, got %s", extended)
+	}
+
+	_, err := state.RenewLease("imaging", pid)
+	if err != nil {
+		t.Fatalf("Could not renew expired lease: %v", err)
+	}
+	state.UpdateServiceStatusses()
+	service = state.GetService("imaging")
+	if service == nil {
+		t.Fatalf("Service was removed after renewing its lease")
+	}
+	if !state.ServiceIsAlive(service) {
+		t.Errorf("Expected service to be alive after renewing its lease")
+	}
+	if service.Status != pb_systemmanager_messages.ServiceStatus_REGISTERED {
+		t.Errorf("Expected status REGISTERED after renewal, got %s", service.Status)
+	}
+	if extended := state.GetExtendedStatus(service); extended != pb_core_extensions.ExtendedServiceStatus_NO_EXTENDED_STATUS {
+		t.Errorf("Expected no extended status after renewal, got %s", extended)
+	}
+}
+
+// A service that does not renew its lease is removed after LeaseRemovalFactor lease durations
+func TestLeaseRemoval(t *testing.T) {
+	state, events := newLeasedState(t)
+
+	expiry := time.Now().Add(2 * MinLeaseDuration)
+	state.CheckLeases(expiry)
+	state.UpdateServiceStatusses()
+	state.CheckLeases(expiry.Add(LeaseRemovalFactor * MinLeaseDuration))
+	if state.GetService("imaging") != nil {
+		t.Fatalf("Expected service to be removed after its lease was expired for too long")
+	}
+
+	if len(*events) != 2 {
+		t.Fatalf("Expected an expiry and a removal event, got %v", *events)
+	}
+	removal := (*events)[1]
+	if !removal.Removed || removal.Reason != pb_core_extensions.ServiceEvent_LEASE_EXPIRED {
+		t.Errorf("Expected removal with reason LEASE_EXPIRED, got %v", removal)
+	}
+}
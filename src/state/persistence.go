@@ -0,0 +1,156 @@
+package state
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	pb_systemmanager_messages "github.com/VU-ASE/rovercom/packages/go/core"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Writes a tuning revision (with its tuning state) to the given file as JSON. The revision is kept along with the state, so that
+// revision numbers continue where they left off after a restart, and clients cannot base an upsert on a revision from before it.
+// The revision is written to a temporary file in the same directory first, which then replaces the old file, so that a crash
+// never leaves a half-written tuning state behind
+func SaveTuningState(path string, revision *pb_core_extensions.TuningRevision) error {
+	content, err := protojson.MarshalOptions{Multiline: true}.Marshal(revision)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	// Does nothing if the file was renamed already
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(content)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	closeErr := tmp.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Reads a tuning revision that was written by SaveTuningState. The timestamp is kept as it was saved,
+// so that the usual precedence rules between tuning values and service defaults still apply.
+// Files that hold a plain TuningState (as written by earlier versions of core) are read as revision 0
+func LoadTuningState(path string) (*pb_core_extensions.TuningRevision, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := &pb_core_extensions.TuningRevision{}
+	err = protojson.Unmarshal(content, revision)
+	if err == nil {
+		if revision.State == nil {
+			revision.State = &pb_systemmanager_messages.TuningState{}
+		}
+		return revision, nil
+	}
+
+	ts := &pb_systemmanager_messages.TuningState{}
+	if protojson.Unmarshal(content, ts) != nil {
+		return nil, fmt.Errorf("Could not parse tuning state '%s': %v", path, err)
+	}
+	return &pb_core_extensions.TuningRevision{
+		Timestamp: ts.Timestamp,
+		State:     ts,
+	}, nil
+}
+
+// Appends audit records to the given file, one JSON object per line. Existing records are never rewritten,
+// so the file holds the entire audit trail, also of the records that no longer fit in memory
+func AppendAuditRecords(path string, records []*pb_core_extensions.TuningAuditRecord) error {
+	var content bytes.Buffer
+	for _, r := range records {
+		line, err := protojson.Marshal(r)
+		if err != nil {
+			return err
+		}
+		content.Write(line)
+		content.WriteByte('\n')
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(content.Bytes())
+	if err == nil {
+		err = file.Sync()
+	}
+	closeErr := file.Close()
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
+
+// Reads the audit records that were written by AppendAuditRecords, oldest first. Records that cannot be parsed are skipped with a
+// warning, so that a damaged audit log does not keep core from starting. A crash while appending can leave the last record
+// half-written, that record is cut off the file so that the next records are appended on a line of their own
+func LoadAuditLog(path string) ([]*pb_core_extensions.TuningAuditRecord, error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	records := make([]*pb_core_extensions.TuningAuditRecord, 0)
+	reader := bufio.NewReader(file)
+	// The offset of the start of the current line
+	offset := int64(0)
+	for line := 1; ; line++ {
+		content, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		// Every record that AppendAuditRecords writes ends with a newline
+		complete := err == nil
+		if len(bytes.TrimSpace(content)) > 0 {
+			r := &pb_core_extensions.TuningAuditRecord{}
+			parseErr := protojson.Unmarshal(content, r)
+			switch {
+			case parseErr == nil:
+				records = append(records, r)
+				if !complete {
+					// Only the newline is missing, add it so that the next record does not end up on the same line
+					_, err = file.WriteAt([]byte("\n"), offset+int64(len(content)))
+					if err != nil {
+						return nil, fmt.Errorf("Could not complete the last audit record in '%s': %v", path, err)
+					}
+				}
+			case !complete:
+				log.Warn().Err(parseErr).Str("path", path).Int("line", line).Msg("Audit log ends with an incomplete record, removing it")
+				err = file.Truncate(offset)
+				if err != nil {
+					return nil, fmt.Errorf("Could not remove incomplete audit record from '%s': %v", path, err)
+				}
+				return records, nil
+			default:
+				log.Warn().Err(parseErr).Str("path", path).Int("line", line).Msg("Skipping audit record that could not be parsed")
+			}
+		}
+		if !complete {
+			break
+		}
+		offset += int64(len(content))
+	}
+	return records, nil
+}
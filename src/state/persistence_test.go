@@ -0,0 +1,51 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	pb_core_extensions "vu/ase/core/src/extensions"
+)
+
+// A crash can leave damaged records behind, which must not keep the audit log from loading
+func TestLoadDamagedAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	records := []*pb_core_extensions.TuningAuditRecord{
+		{Revision: 1, Client: "first"},
+		{Revision: 2, Client: "second"},
+	}
+	err := AppendAuditRecords(path, records[:1])
+	if err != nil {
+		t.Fatalf("Could not write audit log: %v", err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatalf("Could not open audit log: %v", err)
+	}
+	_, err = file.WriteString("not a record\n{\"revision\": \"2\", \"cli")
+	file.Close()
+	if err != nil {
+		t.Fatalf("Could not damage audit log: %v", err)
+	}
+
+	loaded, err := LoadAuditLog(path)
+	if err != nil {
+		t.Fatalf("Could not load damaged audit log: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0].Client != "first" {
+		t.Fatalf("Expected only the first record, got %v", loaded)
+	}
+
+	// The half-written record was removed, so the next one is appended on a line of its own
+	err = AppendAuditRecords(path, records[1:])
+	if err != nil {
+		t.Fatalf("Could not append to audit log: %v", err)
+	}
+	loaded, err = LoadAuditLog(path)
+	if err != nil {
+		t.Fatalf("Could not load audit log: %v", err)
+	}
+	if len(loaded) != 2 || loaded[1].Client != "second" {
+		t.Errorf("Expected the first and second record, got %v", loaded)
+	}
+}
@@ -0,0 +1,118 @@
+package state
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	pb_systemmanager_messages "github.com/VU-ASE/rovercom/packages/go/core"
+	"github.com/rs/zerolog/log"
+)
+
+// The range of ports (inclusive) that core assigns to the outputs of services that ask for one
+type PortRange struct {
+	Min int
+	Max int
+}
+
+// A port that core assigned to an output of a registered service
+type PortAllocation struct {
+	Service string
+	Pid     int32
+	Output  string
+	Port    int
+}
+
+// Parses a port range such as 7000-7999
+func ParsePortRange(value string) (PortRange, error) {
+	minPort, maxPort, found := strings.Cut(value, "-")
+	if !found {
+		return PortRange{}, fmt.Errorf("Port range '%s' is not of the form min-max", value)
+	}
+	min, err := strconv.Atoi(strings.TrimSpace(minPort))
+	if err != nil {
+		return PortRange{}, fmt.Errorf("Port range '%s' has an invalid minimum: %v", value, err)
+	}
+	max, err := strconv.Atoi(strings.TrimSpace(maxPort))
+	if err != nil {
+		return PortRange{}, fmt.Errorf("Port range '%s' has an invalid maximum: %v", value, err)
+	}
+	if min < 1 || max > 65535 || min > max {
+		return PortRange{}, fmt.Errorf("Port range '%s' must lie within 1-65535, with the minimum not larger than the maximum", value)
+	}
+	return PortRange{Min: min, Max: max}, nil
+}
+
+// Adds an endpoint for every output, each with a port from the port range that is neither assigned to another service nor in use on this machine
+func (state *State) allocateOutputs(service *pb_systemmanager_messages.Service, outputs []string) error {
+	if len(outputs) == 0 {
+		return nil
+	}
+	if state.PortRange.Min == 0 {
+		return fmt.Errorf("Core has no port range to assign ports from, declare the addresses of the outputs in the service.yaml instead")
+	}
+
+	allocated := make([]*PortAllocation, 0, len(outputs))
+	for _, output := range outputs {
+		if slices.ContainsFunc(service.Endpoints, func(e *pb_systemmanager_messages.ServiceEndpoint) bool { return e != nil && e.Name == output }) {
+			return fmt.Errorf("Output '%s' already has an address, so it cannot be assigned one", output)
+		}
+		if slices.ContainsFunc(allocated, func(a *PortAllocation) bool { return a.Output == output }) {
+			return fmt.Errorf("Output '%s' was asked for more than once", output)
+		}
+
+		port := state.freePort(allocated)
+		if port == 0 {
+			return fmt.Errorf("There are no free ports left in port range %d-%d to assign to output '%s'", state.PortRange.Min, state.PortRange.Max, output)
+		}
+		allocated = append(allocated, &PortAllocation{
+			Service: service.Identifier.Name,
+			Pid:     service.Identifier.Pid,
+			Output:  output,
+			Port:    port,
+		})
+	}
+
+	// Only record the ports once all outputs have one
+	for _, a := range allocated {
+		log.Info().Str("service", a.Service).Str("output", a.Output).Int("port", a.Port).Msg("Assigned port")
+		service.Endpoints = append(service.Endpoints, &pb_systemmanager_messages.ServiceEndpoint{
+			Name:    a.Output,
+			Address: fmt.Sprintf("tcp://*:%d", a.Port),
+		})
+	}
+	state.ports = append(state.ports, allocated...)
+	return nil
+}
+
+// Returns the lowest port in the port range that is free, or 0 if there is none
+func (state *State) freePort(pending []*PortAllocation) int {
+	for port := state.PortRange.Min; port <= state.PortRange.Max; port++ {
+		taken := func(a *PortAllocation) bool { return a.Port == port }
+		if slices.ContainsFunc(state.ports, taken) || slices.ContainsFunc(pending, taken) {
+			continue
+		}
+
+		// Services that declare their own ports might use this one already
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+		if err != nil {
+			continue
+		}
+		listener.Close()
+		return port
+	}
+	return 0
+}
+
+// Releases the ports of services that are no longer registered
+func (state *State) prunePorts() {
+	state.ports = slices.DeleteFunc(state.ports, func(allocation *PortAllocation) bool {
+		released := !state.isRegistered(allocation.Service, allocation.Pid)
+		if released {
+			log.Info().Str("service", allocation.Service).Str("output", allocation.Output).Int("port", allocation.Port).Msg("Released port")
+		}
+		return released
+	})
+}
@@ -0,0 +1,149 @@
+package state
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"vu/ase/core/src/procutils"
+	"vu/ase/core/src/services"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Decides whether core starts a managed process again after it exited
+type RestartMode string
+
+const (
+	RestartNever     RestartMode = "never"
+	RestartOnFailure RestartMode = "on-failure"
+	RestartAlways    RestartMode = "always"
+)
+
+type RestartPolicy struct {
+	Mode RestartMode
+	// A process that has to be restarted more than MaxRestarts times within Window is considered to be crash-looping,
+	// and will not be restarted anymore
+	MaxRestarts int
+	Window      time.Duration
+}
+
+// A process that core started itself (from the pipeline), as opposed to a service that started on its own and registered.
+// Once it is in the state, its Pid, Exits, Stopping and CrashLooping fields are only changed through UpdateManagedProcess
+type ManagedProcess struct {
+	// The name of the service, as declared in its service.yaml
+	Name          string
+	Pid           int
+	Command       *procutils.ProcessCommand
+	RestartPolicy RestartPolicy
+	// The names of the services this service depends on, as declared in its service.yaml
+	Dependencies []string
+	// The moments at which the process exited unexpectedly, within the restart window
+	Exits []time.Time
+	// Set when core stops the process on purpose, so that it is not restarted
+	Stopping bool
+	// Set when the process exited too often, it will not be restarted until it is explicitly ordered to
+	CrashLooping bool
+}
+
+func (state *State) AddManagedProcess(process *ManagedProcess) {
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	if process != nil {
+		log.Info().Str("name", process.Name).Int("pid", process.Pid).Msg("Added managed process")
+		state.managedProcesses = append(state.managedProcesses, process.clone())
+	}
+}
+
+// Returns a copy of the managed process with the given name, changes to it go through UpdateManagedProcess
+func (state *State) GetManagedProcess(name string) *ManagedProcess {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	return state.getManagedProcess(name).clone()
+}
+
+func (state *State) getManagedProcess(name string) *ManagedProcess {
+	for _, p := range state.managedProcesses {
+		if p != nil && strings.EqualFold(p.Name, name) {
+			return p
+		}
+	}
+	return nil
+}
+
+// Checks whether the service with this name and pid is a managed process that the supervisor gave up on
+func (state *State) isCrashLooping(name string, pid int32) bool {
+	process := state.getManagedProcess(name)
+	return process != nil && process.CrashLooping && process.Pid == int(pid)
+}
+
+// Returns a copy of all managed processes
+func (state *State) GetManagedProcesses() []*ManagedProcess {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	processes := make([]*ManagedProcess, 0, len(state.managedProcesses))
+	for _, p := range state.managedProcesses {
+		if p != nil {
+			processes = append(processes, p.clone())
+		}
+	}
+	return processes
+}
+
+// Calls update with the managed process with the given name while holding the lock, so that reading and changing the process happen at once.
+// Returns false (without calling update) if there is no such process
+func (state *State) UpdateManagedProcess(name string, update func(process *ManagedProcess)) bool {
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	process := state.getManagedProcess(name)
+	if process == nil {
+		return false
+	}
+	update(process)
+	return true
+}
+
+// Builds the dependency graph of all managed processes, used to start and stop them in the right order
+func (state *State) ManagedDependencyGraph() services.DependencyGraph {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	graph := make(services.DependencyGraph)
+	for _, p := range state.managedProcesses {
+		if p != nil {
+			graph[p.Name] = p.Dependencies
+		}
+	}
+	return graph
+}
+
+func (state *State) RemoveManagedProcess(pid int) {
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	state.managedProcesses = slices.DeleteFunc(
+		state.managedProcesses,
+		func(p *ManagedProcess) bool {
+			if p == nil {
+				return true
+			}
+			removed := p.Pid == pid
+			if removed {
+				log.Info().Str("name", p.Name).Int("pid", pid).Msg("Removed managed process")
+			}
+			return removed
+		},
+	)
+}
+
+func (process *ManagedProcess) clone() *ManagedProcess {
+	if process == nil {
+		return nil
+	}
+	copied := *process
+	copied.Exits = slices.Clone(process.Exits)
+	return &copied
+}
@@ -0,0 +1,98 @@
+package state
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	pb_systemmanager_messages "github.com/VU-ASE/rovercom/packages/go/core"
+	"github.com/rs/zerolog/log"
+)
+
+// Issued to a service when it registers. In later requests that change this service, it proves that it is
+// the service that registered by presenting the token
+type Session struct {
+	Token string
+	Name  string
+	Pid   int32
+}
+
+// Creates a session with a new token for a service. It is added to the state when the service registers, see RegisterService
+func newSession(service *pb_systemmanager_messages.Service) (*Session, error) {
+	tokenBytes := make([]byte, 16)
+	_, err := rand.Read(tokenBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Session{
+		Token: hex.EncodeToString(tokenBytes),
+		Name:  service.Identifier.Name,
+		Pid:   service.Identifier.Pid,
+	}, nil
+}
+
+// Adds the session of a registered service, replacing the one it had before (if any)
+func (state *State) addSession(session *Session) {
+	state.sessions = slices.DeleteFunc(state.sessions, func(s *Session) bool {
+		return strings.EqualFold(s.Name, session.Name) && s.Pid == session.Pid
+	})
+	state.sessions = append(state.sessions, session)
+}
+
+func (state *State) GetSession(name string, pid int32) *Session {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	session := state.getSession(name, pid)
+	if session == nil {
+		return nil
+	}
+	copied := *session
+	return &copied
+}
+
+func (state *State) getSession(name string, pid int32) *Session {
+	for _, s := range state.sessions {
+		if s != nil && strings.EqualFold(s.Name, name) && s.Pid == pid {
+			return s
+		}
+	}
+	return nil
+}
+
+// Checks that the token was issued to the given service. Services that registered without a session (using a plain Service message)
+// can only be changed without a token, services that registered with a session can only be changed with their token.
+// Note that this leaves services that registered with a plain Service message (such as every roverlib service) unprotected:
+// any client can change them, only a ServiceRegistration gets a service a session
+func (state *State) Authenticate(name string, pid int32, token string) error {
+	state.lock.RLock()
+	defer state.lock.RUnlock()
+
+	session := state.getSession(name, pid)
+	if session == nil {
+		if token != "" {
+			return fmt.Errorf("Service '%s' (pid %d) did not register with a session, so it cannot present a token", name, pid)
+		}
+		return nil
+	}
+
+	if token == "" {
+		return fmt.Errorf("Service '%s' (pid %d) registered with a session, changes to this service must present the token it was issued", name, pid)
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(session.Token)) != 1 {
+		log.Warn().Str("service", name).Int32("pid", pid).Msg("Rejected request with a token that does not belong to this service")
+		return fmt.Errorf("The presented token was not issued to service '%s' (pid %d)", name, pid)
+	}
+	return nil
+}
+
+// Removes the sessions of services that are no longer registered
+func (state *State) pruneSessions() {
+	state.sessions = slices.DeleteFunc(state.sessions, func(session *Session) bool {
+		return !state.isRegistered(session.Name, session.Pid)
+	})
+}
@@ -0,0 +1,223 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	pb_systemmanager_messages "github.com/VU-ASE/rovercom/packages/go/core"
+)
+
+func intParameter(key string, value int64) *pb_systemmanager_messages.TuningState_Parameter {
+	return &pb_systemmanager_messages.TuningState_Parameter{
+		Parameter: &pb_systemmanager_messages.TuningState_Parameter_Int{
+			Int: &pb_systemmanager_messages.TuningState_Parameter_IntParameter{Key: key, Value: value},
+		},
+	}
+}
+
+// Uses the state from several goroutines at once, the way the request handlers and the background workers do.
+// Run with -race, which fails the test as soon as two of them access the state unsynchronized
+func TestConcurrentAccess(t *testing.T) {
+	state := NewState(nil, "", "", PortRange{})
+	var events atomic.Int64
+	state.OnServiceEvent = func(event *pb_core_extensions.ServiceEvent) {
+		events.Add(1)
+	}
+
+	// Every service is registered by this process, so that its pid exists
+	pid := int32(os.Getpid())
+	const workers = 8
+	const iterations = 50
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				name := fmt.Sprintf("service-%d-%d", w, i%5)
+				service := &pb_systemmanager_messages.Service{
+					Identifier: &pb_systemmanager_messages.ServiceIdentifier{Name: name, Pid: pid},
+					Options: []*pb_systemmanager_messages.ServiceOption{
+						{Name: name + "-speed", Type: pb_systemmanager_messages.ServiceOption_INT, Mutable: true},
+					},
+				}
+				// Registering a service that is still registered fails, which is fine here
+				registered, err := state.RegisterService(service, Registration{
+					WithSession:   i%2 == 0,
+					LeaseDuration: MinLeaseDuration,
+				})
+				if err == nil {
+					// The returned service is a copy, changing it must not touch the state
+					registered.Service.Status = pb_systemmanager_messages.ServiceStatus_STOPPED
+				}
+
+				_, err = state.ApplyTuningUpdate(TuningUpdate{
+					State: &pb_systemmanager_messages.TuningState{
+						DynamicParameters: []*pb_systemmanager_messages.TuningState_Parameter{intParameter(name+"-speed", int64(i))},
+					},
+					Merge:  true,
+					Client: name,
+				})
+				if err != nil {
+					t.Errorf("Could not update tuning state: %v", err)
+				}
+
+				state.UpdateServiceStatusses()
+				// Some of the leases expire, and some of those are renewed again
+				state.CheckLeases(time.Now().Add(time.Duration(i%4) * MinLeaseDuration))
+				_, _ = state.RenewLease(name, pid)
+				_, _ = state.UpdateServiceStatus(name, pid, pb_systemmanager_messages.ServiceStatus_RUNNING)
+
+				for _, s := range state.GetServices() {
+					state.GetServiceStatus(s)
+					state.GetExtendedStatus(s)
+				}
+				state.GetService(name)
+				state.GetServiceByIdentifier(name, pid)
+				state.GetServiceInstances(name)
+				state.GetEndpoints(name, pid)
+				state.GetLease(name, pid)
+				state.GetSession(name, pid)
+				state.GetServiceOption(name + "-speed")
+				state.GetOptionConstraint(name + "-speed")
+				state.GetTuningState()
+				state.GetCurrentTuningRevision()
+				state.GetTuningRevisions()
+				state.GetTuningAudit(name+"-speed", 0, 0)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if revision := state.GetTuningRevisionNumber(); revision != workers*iterations {
+		t.Errorf("Expected %d tuning revisions, got %d", workers*iterations, revision)
+	}
+	if events.Load() == 0 {
+		t.Errorf("Expected service events for the expired and renewed leases, got none")
+	}
+}
+
+// What the accessors return must be a copy, so that callers can use it without holding the lock
+func TestAccessorsReturnCopies(t *testing.T) {
+	state := NewState(nil, "", "", PortRange{})
+	pid := int32(os.Getpid())
+	_, err := state.RegisterService(&pb_systemmanager_messages.Service{
+		Identifier: &pb_systemmanager_messages.ServiceIdentifier{Name: "imaging", Pid: pid},
+	}, Registration{})
+	if err != nil {
+		t.Fatalf("Could not register service: %v", err)
+	}
+
+	state.GetService("imaging").Status = pb_systemmanager_messages.ServiceStatus_STOPPED
+	state.GetServices()[0].Identifier.Name = "renamed"
+	service := state.GetService("imaging")
+	if service == nil {
+		t.Fatalf("Service was renamed through a returned copy")
+	}
+	if service.Status != pb_systemmanager_messages.ServiceStatus_REGISTERED {
+		t.Errorf("Expected status REGISTERED, got %s", service.Status)
+	}
+
+	_, err = state.ApplyTuningUpdate(TuningUpdate{
+		State: &pb_systemmanager_messages.TuningState{
+			DynamicParameters: []*pb_systemmanager_messages.TuningState_Parameter{intParameter("speed", 1)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Could not update tuning state: %v", err)
+	}
+	state.GetTuningState().DynamicParameters[0].GetInt().Value = 2
+	if value := FindTuningParameter(state.GetTuningState(), "speed").GetInt().GetValue(); value != 1 {
+		t.Errorf("Expected speed 1, got %d", value)
+	}
+}
+
+// A merge only changes the parameters that it names, the others keep the value that services see, even when that is the
+// default of a service that registered after the last change
+func TestMergeKeepsEffectiveDefaults(t *testing.T) {
+	state := NewState(nil, "", "", PortRange{})
+	_, err := state.ApplyTuningUpdate(TuningUpdate{
+		State: &pb_systemmanager_messages.TuningState{
+			DynamicParameters: []*pb_systemmanager_messages.TuningState_Parameter{intParameter("speed", 5)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Could not update tuning state: %v", err)
+	}
+
+	// The registration must be later than the tuning state for its default to win
+	time.Sleep(2 * time.Millisecond)
+	_, err = state.RegisterService(&pb_systemmanager_messages.Service{
+		Identifier: &pb_systemmanager_messages.ServiceIdentifier{Name: "imaging", Pid: int32(os.Getpid())},
+		Options: []*pb_systemmanager_messages.ServiceOption{
+			{Name: "speed", Type: pb_systemmanager_messages.ServiceOption_INT, Mutable: true, IntDefault: 3},
+		},
+	}, Registration{})
+	if err != nil {
+		t.Fatalf("Could not register service: %v", err)
+	}
+	if value := FindTuningParameter(state.GetTuningState(), "speed").GetInt().GetValue(); value != 3 {
+		t.Fatalf("Expected the default speed 3 of the newer service, got %d", value)
+	}
+
+	revision, err := state.ApplyTuningUpdate(TuningUpdate{
+		State: &pb_systemmanager_messages.TuningState{
+			DynamicParameters: []*pb_systemmanager_messages.TuningState_Parameter{intParameter("angle", 10)},
+		},
+		Merge: true,
+	})
+	if err != nil {
+		t.Fatalf("Could not merge tuning state: %v", err)
+	}
+	tuning := state.GetTuningState()
+	if value := FindTuningParameter(tuning, "speed").GetInt().GetValue(); value != 3 {
+		t.Errorf("Expected speed to stay 3 after merging angle, got %d", value)
+	}
+	if value := FindTuningParameter(tuning, "angle").GetInt().GetValue(); value != 10 {
+		t.Errorf("Expected angle 10 after the merge, got %d", value)
+	}
+	if len(revision.ChangedKeys) != 1 || revision.ChangedKeys[0] != "angle" {
+		t.Errorf("Expected only angle to have changed, got %v", revision.ChangedKeys)
+	}
+}
+
+// A service that the supervisor gave up on stays queryable as crash-looping, rather than disappearing like a service whose process is gone
+func TestCrashLoopingServiceIsKept(t *testing.T) {
+	state := NewState(nil, "", "", PortRange{})
+	// A pid above the maximum pid of Linux, so that no process has it
+	const pid = 1 << 23
+	_, err := state.RegisterService(&pb_systemmanager_messages.Service{
+		Identifier: &pb_systemmanager_messages.ServiceIdentifier{Name: "imaging", Pid: pid},
+	}, Registration{})
+	if err != nil {
+		t.Fatalf("Could not register service: %v", err)
+	}
+	state.AddManagedProcess(&ManagedProcess{Name: "imaging", Pid: pid, CrashLooping: true})
+
+	state.UpdateServiceStatusses()
+	service := state.GetService("imaging")
+	if service == nil {
+		t.Fatalf("Crash-looping service was removed")
+	}
+	if service.Status != pb_systemmanager_messages.ServiceStatus_STOPPED {
+		t.Errorf("Expected status STOPPED, got %s", service.Status)
+	}
+	if extended := state.GetExtendedStatus(service); extended != pb_core_extensions.ExtendedServiceStatus_CRASH_LOOPING {
+		t.Errorf("Expected extended status CRASH_LOOPING, got %s", extended)
+	}
+
+	// Once it is relaunched, the old registration is gone like that of any other exited process
+	state.UpdateManagedProcess("imaging", func(p *ManagedProcess) {
+		p.Pid = os.Getpid()
+		p.CrashLooping = false
+	})
+	state.UpdateServiceStatusses()
+	if state.GetService("imaging") != nil {
+		t.Errorf("Expected the registration of the exited process to be removed after the relaunch")
+	}
+}
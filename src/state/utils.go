@@ -18,6 +18,11 @@ func findParameter(key string, params []*pb_systemmanager_messages.TuningState_P
 	return nil
 }
 
+// Returns the parameter with the given key from the tuning state, or nil if there is none
+func FindTuningParameter(ts *pb_systemmanager_messages.TuningState, key string) *pb_systemmanager_messages.TuningState_Parameter {
+	return findParameter(key, ts.GetDynamicParameters())
+}
+
 // Parses a parameter from the tuning state and returns the key
 func getKeyAndType(param *pb_systemmanager_messages.TuningState_Parameter) (string, string) {
 	if param.GetString_() != nil {
@@ -0,0 +1,272 @@
+package supervisor
+
+import (
+	"fmt"
+	"slices"
+	"time"
+	"vu/ase/core/src/procutils"
+	"vu/ase/core/src/services"
+	"vu/ase/core/src/state"
+
+	pb_core_extensions "vu/ase/core/src/extensions"
+
+	pb_core_messages "github.com/VU-ASE/rovercom/packages/go/core"
+	"github.com/rs/zerolog/log"
+)
+
+// How long a process gets to shut down gracefully after receiving SIGTERM
+const gracefulStopTimeout = 10 * time.Second
+
+// How long we wait for a process to disappear after receiving SIGKILL
+const forcedStopTimeout = 2 * time.Second
+
+// How long a service may take to register after it was launched, before the services that depend on it are launched anyway
+const startupTimeout = 30 * time.Second
+
+// How often the registry is checked for the dependencies of a service that is waiting to be launched
+const startupPollInterval = 100 * time.Millisecond
+
+// The delay before the first restart of a process, doubled for every restart within the restart window
+const initialRestartDelay = 1 * time.Second
+
+// The delay between restarts will never grow beyond this
+const maxRestartDelay = 30 * time.Second
+
+// Called when the supervisor changes the status of a service on its own accord (e.g. when it detects a crash loop), so that
+// it can be broadcast. This is set by main, since the server package depends on the supervisor and not the other way around
+var OnStatusChange func(service *pb_core_messages.Service, extendedStatus pb_core_extensions.ExtendedServiceStatus)
+
+// Starts all services from the pipeline file as child processes of core. A service is only launched once the services it depends on
+// have registered (or did not do so within the startup timeout), so this happens in the background: services can only register
+// once the server is up. If one of them cannot be started, the ones that were already started are stopped again
+func LaunchPipeline(path string, systemState *state.State) error {
+	processes, err := ParsePipeline(path)
+	if err != nil {
+		return err
+	}
+
+	// Services are started after the services they depend on
+	graph := make(services.DependencyGraph)
+	for _, p := range processes {
+		graph[p.Name] = p.Dependencies
+	}
+	order, err := graph.TopologicalOrder()
+	if err != nil {
+		return fmt.Errorf("Could not launch pipeline '%s': %v", path, err)
+	}
+
+	log.Info().Str("pipeline", path).Strs("order", order).Msg("Launching pipeline")
+	go func() {
+		for _, name := range order {
+			p := processes[slices.IndexFunc(processes, func(p *state.ManagedProcess) bool { return p.Name == name })]
+			waitForDependencies(p, systemState)
+			err := Launch(p, systemState)
+			if err != nil {
+				log.Err(err).Str("pipeline", path).Str("service", p.Name).Msg("Could not launch service from pipeline, stopping the pipeline")
+				StopAll(systemState)
+				return
+			}
+		}
+		log.Info().Str("pipeline", path).Msg("Launched pipeline")
+	}()
+	return nil
+}
+
+// Blocks until every dependency of the process has registered, or until the startup timeout has passed
+func waitForDependencies(process *state.ManagedProcess, systemState *state.State) {
+	deadline := time.Now().Add(startupTimeout)
+	for _, dependency := range process.Dependencies {
+		for systemState.GetService(dependency) == nil {
+			if time.Now().After(deadline) {
+				log.Warn().Str("service", process.Name).Str("dependency", dependency).Str("timeout", startupTimeout.String()).Msg("Dependency did not register in time, launching service anyway")
+				break
+			}
+			time.Sleep(startupPollInterval)
+		}
+	}
+}
+
+// Starts (or restarts) a managed process and keeps track of it in the state. When the process exits, its restart policy decides what happens next
+func Launch(process *state.ManagedProcess, systemState *state.State) error {
+	started, err := procutils.StartProcess(process.Command)
+	if err != nil {
+		return err
+	}
+	log.Info().Str("service", process.Name).Int("pid", started.Pid).Msg("Launched service")
+
+	markStarted := func(p *state.ManagedProcess) {
+		p.Pid = started.Pid
+		p.Stopping = false
+		p.CrashLooping = false
+	}
+	markStarted(process)
+	if !systemState.UpdateManagedProcess(process.Name, markStarted) {
+		systemState.AddManagedProcess(process)
+	}
+
+	go supervise(process, started, systemState)
+	return nil
+}
+
+// Waits for a managed process to exit and restarts it if its restart policy says so. The process is only read and
+// changed through the state, since others (e.g. service orders) can change it in the meantime
+func supervise(process *state.ManagedProcess, started *procutils.StartedProcess, systemState *state.State) {
+	err := <-started.Exited
+
+	// The process might have been restarted by someone else already, then this is no longer ours to handle
+	var ours, stopping bool
+	systemState.UpdateManagedProcess(process.Name, func(p *state.ManagedProcess) {
+		ours = p.Pid == started.Pid
+		stopping = p.Stopping
+	})
+	if !ours {
+		return
+	}
+
+	if stopping {
+		log.Info().Str("service", process.Name).Int("pid", started.Pid).Msg("Managed service stopped")
+		systemState.RemoveManagedProcess(started.Pid)
+		return
+	}
+
+	if err != nil {
+		log.Warn().Err(err).Str("service", process.Name).Int("pid", started.Pid).Msg("Managed service exited unexpectedly")
+	} else {
+		log.Info().Str("service", process.Name).Int("pid", started.Pid).Msg("Managed service exited")
+	}
+
+	policy := process.RestartPolicy
+	if policy.Mode == state.RestartNever || (policy.Mode == state.RestartOnFailure && err == nil) {
+		systemState.RemoveManagedProcess(started.Pid)
+		return
+	}
+
+	// Only the exits within the window count towards crash-loop detection
+	now := time.Now()
+	var exits int
+	var crashLooping bool
+	systemState.UpdateManagedProcess(process.Name, func(p *state.ManagedProcess) {
+		recentExits := make([]time.Time, 0, len(p.Exits)+1)
+		for _, t := range p.Exits {
+			if now.Sub(t) <= policy.Window {
+				recentExits = append(recentExits, t)
+			}
+		}
+		p.Exits = append(recentExits, now)
+		exits = len(p.Exits)
+		crashLooping = exits > policy.MaxRestarts
+		p.CrashLooping = crashLooping
+	})
+
+	if crashLooping {
+		log.Error().Str("service", process.Name).Int("exits", exits).Str("window", policy.Window.String()).Msg("Managed service is crash-looping, it will not be restarted")
+		if OnStatusChange != nil {
+			OnStatusChange(&pb_core_messages.Service{
+				Identifier: &pb_core_messages.ServiceIdentifier{
+					Name: process.Name,
+					Pid:  int32(started.Pid),
+				},
+				Status: pb_core_messages.ServiceStatus_STOPPED,
+			}, pb_core_extensions.ExtendedServiceStatus_CRASH_LOOPING)
+		}
+		return
+	}
+
+	delay := restartDelay(exits)
+	log.Info().Str("service", process.Name).Str("delay", delay.String()).Msg("Restarting managed service")
+	time.Sleep(delay)
+
+	// Core might have been told to stop the process (or terminate) in the meantime
+	systemState.UpdateManagedProcess(process.Name, func(p *state.ManagedProcess) {
+		ours = !p.Stopping && p.Pid == started.Pid
+	})
+	if !ours {
+		return
+	}
+	err = Launch(process, systemState)
+	if err != nil {
+		log.Err(err).Str("service", process.Name).Msg("Failed to restart managed service")
+		systemState.RemoveManagedProcess(started.Pid)
+	}
+}
+
+// Exponential backoff: the delay doubles for every restart within the restart window
+func restartDelay(restarts int) time.Duration {
+	delay := initialRestartDelay
+	for i := 1; i < restarts && delay < maxRestartDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRestartDelay)
+}
+
+// Stops a process on purpose. If core manages this process, it will not be restarted
+func Stop(name string, pid int, force bool, systemState *state.State) error {
+	systemState.UpdateManagedProcess(name, func(p *state.ManagedProcess) {
+		if p.Pid == pid {
+			p.Stopping = true
+		}
+	})
+	return StopProcess(name, pid, force)
+}
+
+// Stops a process and waits for it to exit. If force is set, the process is killed immediately instead of being asked to shut down
+func StopProcess(name string, pid int, force bool) error {
+	var err error
+	timeout := gracefulStopTimeout
+	if force {
+		log.Info().Str("service", name).Int("pid", pid).Msg("Killing process")
+		err = procutils.ForceKillProcess(pid)
+		timeout = forcedStopTimeout
+	} else {
+		log.Info().Str("service", name).Int("pid", pid).Msg("Stopping process")
+		err = procutils.KillProcess(pid)
+	}
+	// The process might have exited in the meantime, which is what we wanted anyway
+	if err != nil && procutils.ProcessExists(pid) {
+		return fmt.Errorf("Could not stop '%s' (pid %d): %v", name, pid, err)
+	}
+
+	if !procutils.WaitForExit(pid, timeout) {
+		return fmt.Errorf("'%s' (pid %d) did not stop within %v", name, pid, timeout)
+	}
+	return nil
+}
+
+// Stops all processes that core started, in reverse dependency order: a service is stopped before the services it depends on.
+// Processes that do not shut down gracefully in time are killed
+func StopAll(systemState *state.State) {
+	// Iterate over a copy, since exiting processes remove themselves from the state
+	processes := systemState.GetManagedProcesses()
+
+	order, err := systemState.ManagedDependencyGraph().TopologicalOrder()
+	if err != nil {
+		// Processes that core adopted later on could have introduced a cycle, still stop everything
+		log.Warn().Err(err).Msg("Could not determine shutdown order, stopping processes in launch order")
+	} else {
+		slices.Reverse(order)
+		slices.SortStableFunc(processes, func(a, b *state.ManagedProcess) int {
+			return slices.Index(order, a.Name) - slices.Index(order, b.Name)
+		})
+	}
+
+	for _, p := range processes {
+		// Crash-looping processes are not running anymore
+		systemState.UpdateManagedProcess(p.Name, func(p *state.ManagedProcess) {
+			p.Stopping = true
+		})
+		if p.CrashLooping {
+			continue
+		}
+
+		err := StopProcess(p.Name, p.Pid, false)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Failed to stop '%v' process gracefully, killing it", p.Name)
+			err = StopProcess(p.Name, p.Pid, true)
+		}
+		if err != nil {
+			log.Err(err).Msgf("Failed to kill '%v' process", p.Name)
+		} else {
+			log.Info().Msgf("Stopped '%v' process", p.Name)
+		}
+	}
+}
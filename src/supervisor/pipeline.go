@@ -0,0 +1,167 @@
+package supervisor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+	"vu/ase/core/src/procutils"
+	"vu/ase/core/src/state"
+
+	"github.com/go-yaml/yaml"
+)
+
+//
+// A pipeline file lists the services that core should start itself. Each service lives in its own directory,
+// next to its own service.yaml. For example:
+//
+//	crashLoop:
+//	  maxRestarts: 5
+//	  window: 1m
+//	services:
+//	  - path: ../imaging
+//	    restart: on-failure
+//	  - path: ../controller
+//	    command: bin/controller
+//	    args: ["-debug"]
+//
+
+// Used when the pipeline file does not configure crash-loop detection
+const defaultMaxRestarts = 5
+const defaultRestartWindow = 1 * time.Minute
+
+type pipelineDefinition struct {
+	CrashLoop crashLoopDefinition `yaml:"crashLoop"`
+	Services  []pipelineService   `yaml:"services"`
+}
+
+type crashLoopDefinition struct {
+	// the number of restarts allowed within the window, before a service is considered crash-looping
+	MaxRestarts int           `yaml:"maxRestarts"`
+	Window      time.Duration `yaml:"window"`
+}
+
+type pipelineService struct {
+	// the directory containing the service.yaml of this service, relative to the pipeline file
+	Path string `yaml:"path"`
+	// the executable to run, relative to Path. Defaults to bin/<name>, which is where the service Makefiles build to
+	Command string `yaml:"command"`
+	// extra arguments to pass to the executable
+	Args []string `yaml:"args"`
+	// what to do when the service exits: never (default), on-failure or always restart it
+	Restart string `yaml:"restart"`
+}
+
+// The parts of a service.yaml that core needs to be able to launch the service
+type serviceDefinition struct {
+	Name         string `yaml:"name"`
+	Dependencies []struct {
+		ServiceName string `yaml:"service"`
+		OutputName  string `yaml:"output"`
+	} `yaml:"dependencies"`
+}
+
+// Reads the pipeline file and the service.yaml of every service listed in it, and returns the processes to launch
+func ParsePipeline(path string) ([]*state.ManagedProcess, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pipeline := pipelineDefinition{}
+	err = yaml.Unmarshal(content, &pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse pipeline file '%s': %v", path, err)
+	}
+
+	// All service paths are relative to the pipeline file
+	baseDir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	maxRestarts := pipeline.CrashLoop.MaxRestarts
+	if maxRestarts <= 0 {
+		maxRestarts = defaultMaxRestarts
+	}
+	window := pipeline.CrashLoop.Window
+	if window <= 0 {
+		window = defaultRestartWindow
+	}
+
+	processes := make([]*state.ManagedProcess, 0, len(pipeline.Services))
+	for _, s := range pipeline.Services {
+		if s.Path == "" {
+			return nil, fmt.Errorf("Pipeline file '%s' contains a service without a path", path)
+		}
+		serviceDir := s.Path
+		if !filepath.IsAbs(serviceDir) {
+			serviceDir = filepath.Join(baseDir, serviceDir)
+		}
+
+		definition, err := parseServiceDefinition(filepath.Join(serviceDir, "service.yaml"))
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range processes {
+			if p.Name == definition.Name {
+				return nil, fmt.Errorf("Pipeline file '%s' contains service '%s' more than once", path, definition.Name)
+			}
+		}
+
+		executable := s.Command
+		if executable == "" {
+			executable = filepath.Join("bin", definition.Name)
+		}
+		if !filepath.IsAbs(executable) {
+			executable = filepath.Join(serviceDir, executable)
+		}
+
+		mode := state.RestartMode(s.Restart)
+		if mode == "" {
+			mode = state.RestartNever
+		} else if mode != state.RestartNever && mode != state.RestartOnFailure && mode != state.RestartAlways {
+			return nil, fmt.Errorf("Service '%s' in pipeline file '%s' has an unknown restart policy '%s' (use never, on-failure or always)", definition.Name, path, s.Restart)
+		}
+
+		dependencies := make([]string, 0, len(definition.Dependencies))
+		for _, d := range definition.Dependencies {
+			if !slices.Contains(dependencies, d.ServiceName) {
+				dependencies = append(dependencies, d.ServiceName)
+			}
+		}
+
+		processes = append(processes, &state.ManagedProcess{
+			Name: definition.Name,
+			Command: &procutils.ProcessCommand{
+				Path: executable,
+				Args: append([]string{executable}, s.Args...),
+				Dir:  serviceDir,
+			},
+			RestartPolicy: state.RestartPolicy{
+				Mode:        mode,
+				MaxRestarts: maxRestarts,
+				Window:      window,
+			},
+			Dependencies: dependencies,
+		})
+	}
+
+	return processes, nil
+}
+
+func parseServiceDefinition(path string) (*serviceDefinition, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	definition := serviceDefinition{}
+	err = yaml.Unmarshal(content, &definition)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse service definition '%s': %v", path, err)
+	}
+	if definition.Name == "" {
+		return nil, fmt.Errorf("Service definition '%s' does not have a name", path)
+	}
+	return &definition, nil
+}